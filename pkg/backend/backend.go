@@ -2,9 +2,12 @@ package backend
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/connector"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/framer"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
@@ -24,9 +27,19 @@ type Backend interface {
 	Dispose()
 }
 
+// defaultQueryLimits prevent that a query which spans a very long period exhausts the memory of the plugin
+var defaultQueryLimits = models.QueryLimits{
+	MaxPages:  1000,
+	MaxPoints: 10_000_000,
+	MaxBytes:  256 << 20,
+}
+
 type backendImpl struct {
-	client client.BackendAPIClient
-	conn   *grpc.ClientConn
+	client   client.BackendAPIClient
+	conn     *grpc.ClientConn
+	settings client.BackendAPIDatasourceSettings
+	options  *optionsCache
+	catalog  *metricCatalog
 }
 
 func New(settings backend.DataSourceInstanceSettings) (Backend, error) {
@@ -39,36 +52,97 @@ func New(settings backend.DataSourceInstanceSettings) (Backend, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &backendImpl{
-		client: cl,
-	}, nil
+	ds := &backendImpl{
+		client:   cl,
+		settings: cfg,
+		options:  newOptionsCache(),
+	}
+	ds.catalog = newMetricCatalog(settings.UID, time.Duration(cfg.MetricCatalogTTL)*time.Second, ds.GetMetrics)
+	return ds, nil
+}
+
+// applyLimits sets the limits which apply to a paginated query
+func (ds *backendImpl) applyLimits(query *models.MetricBaseQuery) {
+	query.Limits = query.Limits.Within(ds.settings.QueryLimits.WithDefaults(defaultQueryLimits))
+	query.HardLimits = ds.settings.HardLimits
 }
 
 func (ds *backendImpl) HandleGetMetricValueQuery(ctx context.Context, query *models.MetricValueQuery) (data.Frames, error) {
-	//TODO: remove pointer dereference
-	res, err := connector.GetMetricValue(ctx, ds.client, *query)
-	if err != nil {
-		return nil, err
-	}
-	return res.Frames()
+	frames, err := ds.fanOut(ctx, query.MetricBaseQuery, func(ctx context.Context, q models.MetricBaseQuery) (data.Frames, error) {
+		valueQuery := *query
+		valueQuery.MetricBaseQuery = q
+		res, err := connector.GetMetricValue(ctx, ds.client, valueQuery)
+		if err != nil {
+			return nil, err
+		}
+		return res.Frames()
+	})
+	return ds.combineSeries(ctx, query.MetricBaseQuery, frames, err)
 }
 
 func (ds *backendImpl) HandleGetMetricHistoryQuery(ctx context.Context, query *models.MetricHistoryQuery) (data.Frames, error) {
-	//TODO: remove pointer dereference
-	res, err := connector.GetMetricHistory(ctx, ds.client, *query)
-	if err != nil {
-		return backendErrorResponse(err)
-	}
-	return res.Frames()
+	ds.applyLimits(&query.MetricBaseQuery)
+	frames, err := ds.fanOut(ctx, query.MetricBaseQuery, func(ctx context.Context, q models.MetricBaseQuery) (data.Frames, error) {
+		historyQuery := *query
+		historyQuery.MetricBaseQuery = q
+		res, err := connector.GetMetricHistory(ctx, ds.client, historyQuery)
+		var partial *connector.PartialResultError
+		if errors.As(err, &partial) {
+			return partialResponse(res.Frames, partial)
+		}
+		if err != nil {
+			return backendErrorResponse(err)
+		}
+		return res.Frames()
+	})
+	return ds.combineSeries(ctx, query.MetricBaseQuery, frames, err)
 }
 
 func (ds *backendImpl) HandleGetMetricAggregateQuery(ctx context.Context, query *models.MetricAggregateQuery) (data.Frames, error) {
-	//TODO: remove pointer dereference
-	res, err := connector.GetMetricAggregate(ctx, ds.client, *query)
-	if err != nil {
-		return backendErrorResponse(err)
+	ds.applyLimits(&query.MetricBaseQuery)
+	frames, err := ds.fanOut(ctx, query.MetricBaseQuery, func(ctx context.Context, q models.MetricBaseQuery) (data.Frames, error) {
+		aggregateQuery := *query
+		aggregateQuery.MetricBaseQuery = q
+		res, err := connector.GetMetricAggregate(ctx, ds.client, aggregateQuery)
+		var partial *connector.PartialResultError
+		if errors.As(err, &partial) {
+			return partialResponse(res.Frames, partial)
+		}
+		if err != nil {
+			return backendErrorResponse(err)
+		}
+		return res.Frames()
+	})
+	return ds.combineSeries(ctx, query.MetricBaseQuery, frames, err)
+}
+
+// combineSeries applies the group by, the ranking and the naming strategy of a query to the frames of a (partial) result
+func (ds *backendImpl) combineSeries(ctx context.Context, query models.MetricBaseQuery, frames data.Frames, err error) (data.Frames, error) {
+	if frames == nil {
+		return frames, err
+	}
+	if query.GroupBy != nil {
+		grouped, groupErr := framer.GroupBy(frames, *query.GroupBy)
+		if groupErr != nil {
+			return nil, groupErr
+		}
+		frames = grouped
+	}
+	if query.Ranking != nil {
+		ranked, rankErr := framer.Rank(frames, *query.Ranking)
+		if rankErr != nil {
+			return nil, rankErr
+		}
+		frames = ranked
+	}
+	strategy := query.NamingStrategy
+	if strategy == "" {
+		strategy = ds.settings.NamingStrategy
 	}
-	return res.Frames()
+	// the catalog is a best effort; if the metrics can not be listed, the frames are returned as is
+	catalog := ds.catalog.get(ctx, query.Dimensions)
+	frames = framer.NameSeries(frames, strategy, query.Dimensions, catalog)
+	return framer.Enrich(frames, catalog), err
 }
 
 func (ds *backendImpl) GetDimensionKeys(ctx context.Context, query models.GetDimensionKeysRequest) (*models.GetDimensionKeysResponse, error) {
@@ -96,9 +170,10 @@ func (ds *backendImpl) GetMetrics(ctx context.Context, query models.GetMetricsRe
 }
 
 func (backendimpl *backendImpl) GetQueryOptions(ctx context.Context, input models.GetQueryOptionsRequest) (*models.GetQueryOptionsResponse, error) {
-	return connector.GetQueryOptionDefinitions(ctx, backendimpl.client, input)
+	return backendimpl.options.get(ctx, backendimpl.client, input)
 }
 
 func (ds *backendImpl) Dispose() {
+	ds.catalog.dispose()
 	ds.client.Dispose()
 }
@@ -4,7 +4,7 @@ import (
 	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client/factory"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	grpc_retry "github.com/grpc-ecosystem/go-grpc-middleware/retry"
 	"google.golang.org/grpc"
@@ -15,7 +15,7 @@ import (
 
 type backendClient struct {
 	conn *grpc.ClientConn
-	v3.GrafanaQueryAPIClient
+	v5.GrafanaQueryAPIClient
 }
 
 func (b *backendClient) Dispose() {
@@ -55,8 +55,8 @@ func New(settings BackendAPIDatasourceSettings) (BackendAPIClient, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &backendClient{
+	return newDedupClient(&backendClient{
 		conn:                  conn,
 		GrafanaQueryAPIClient: c,
-	}, nil
+	}), nil
 }
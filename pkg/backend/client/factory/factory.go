@@ -6,26 +6,53 @@ import (
 	v1client "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client/v1"
 	v2client "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client/v2"
 	v3client "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client/v3"
+	v4client "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client/v4"
 	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/jhump/protoreflect/grpcreflect"
 	"google.golang.org/grpc"
 	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
-func NewClient(conn *grpc.ClientConn) (v3.GrafanaQueryAPIClient, error) {
+func NewClient(conn *grpc.ClientConn) (v5.GrafanaQueryAPIClient, error) {
 	stub := rpb.NewServerReflectionClient(conn)
 
 	c := grpcreflect.NewClient(context.Background(), stub)
+	if _, err := c.ResolveService("grafanav5.GrafanaQueryAPI"); err == nil {
+		backend.Logger.Info("use v5 version of the backend API")
+		return v5.NewGrafanaQueryAPIClient(conn), nil
+	}
+	if _, err := c.ResolveService("grafanav4.GrafanaQueryAPI"); err == nil {
+		backend.Logger.Info("use v4 version of the backend API")
+		return v4client.NewClient(conn)
+	}
 	if _, err := c.ResolveService("grafanav3.GrafanaQueryAPI"); err == nil {
 		backend.Logger.Info("use v3 version of the backend API")
-		return v3client.NewClient(conn)
+		return wrap(v3client.NewClient(conn))
 	}
 	_, err := c.ResolveService("grafanav2.GrafanaQueryAPI")
 	if err == nil {
 		backend.Logger.Info("use v2 version of the backend API")
-		return v2client.NewClient(conn)
+		return adapt(v2client.NewClient(conn))
 	}
 	backend.Logger.Info("use default version of the backend API")
-	return v1client.NewClient(conn)
+	return adapt(v1client.NewClient(conn))
+}
+
+// adapt converts the v3 adapter of an older API version to a v5 client
+func adapt(client v3.GrafanaQueryAPIClient, err error) (v5.GrafanaQueryAPIClient, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v4client.Wrap(v3client.Wrap(client)), nil
+}
+
+// wrap converts a v4 client to a v5 client
+func wrap(client v4.GrafanaQueryAPIClient, err error) (v5.GrafanaQueryAPIClient, error) {
+	if err != nil {
+		return nil, err
+	}
+	return v4client.Wrap(client), nil
 }
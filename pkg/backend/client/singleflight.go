@@ -0,0 +1,171 @@
+package client
+
+import (
+	"context"
+	"sync"
+
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// inflightCall is a backend call which is shared by all callers which issued an identical request.
+type inflightCall struct {
+	done    chan struct{}
+	res     proto.Message
+	err     error
+	waiters int
+	shared  bool
+	cancel  context.CancelFunc
+}
+
+// callGroup deduplicates identical concurrent calls. The shared call does not run on the context
+// of the caller which started it; it is only canceled when every waiting caller has given up.
+type callGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+func (g *callGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (proto.Message, error)) (proto.Message, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*inflightCall{}
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		c = g.start(ctx, key, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		if c.shared && c.res != nil {
+			// callers are free to modify the response (e.g. while merging pages)
+			return proto.Clone(c.res), c.err
+		}
+		return c.res, c.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		c.waiters--
+		if c.waiters == 0 {
+			// nobody is interested in the result anymore
+			c.cancel()
+			g.forget(key, c)
+		}
+		g.mu.Unlock()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+// start runs fn on a context which keeps the values (e.g. outgoing metadata) of the first caller but which is
+// neither canceled nor bound by the deadline of that caller; every caller waits until its own deadline, so the call
+// runs until the latest deadline of the callers which joined it.
+// It must be called with g.mu held.
+func (g *callGroup) start(ctx context.Context, key string, fn func(ctx context.Context) (proto.Message, error)) *inflightCall {
+	callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	c := &inflightCall{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+	g.calls[key] = c
+
+	go func() {
+		defer cancel()
+		res, err := fn(callCtx)
+
+		g.mu.Lock()
+		c.res, c.err = res, err
+		c.shared = c.waiters > 1
+		g.forget(key, c)
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	return c
+}
+
+// forget removes the call from the group so that new callers start a new call.
+// It must be called with g.mu held.
+func (g *callGroup) forget(key string, c *inflightCall) {
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+}
+
+// requestKey returns a key which is identical for identical requests of the same method.
+func requestKey(method string, req proto.Message) (string, bool) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", false
+	}
+	return method + "/" + string(b), true
+}
+
+// dedupClient shares a single backend call between identical concurrent requests, which occurs quite often
+// for alert rules, wallboards and dashboards which are viewed by several users at the same time.
+type dedupClient struct {
+	BackendAPIClient
+	group callGroup
+}
+
+func newDedupClient(c BackendAPIClient) BackendAPIClient {
+	return &dedupClient{BackendAPIClient: c}
+}
+
+func dedup[Req proto.Message, Res proto.Message](ctx context.Context, g *callGroup, method string, in Req, fn func(ctx context.Context, in Req) (Res, error)) (Res, error) {
+	key, ok := requestKey(method, in)
+	if !ok {
+		return fn(ctx, in)
+	}
+	res, err := g.do(ctx, key, func(ctx context.Context) (proto.Message, error) {
+		return fn(ctx, in)
+	})
+	if err != nil {
+		var zero Res
+		return zero, err
+	}
+	return res.(Res), nil
+}
+
+func (c *dedupClient) ListDimensionKeys(ctx context.Context, in *v5.ListDimensionKeysRequest, opts ...grpc.CallOption) (*v5.ListDimensionKeysResponse, error) {
+	return dedup(ctx, &c.group, "ListDimensionKeys", in, func(ctx context.Context, in *v5.ListDimensionKeysRequest) (*v5.ListDimensionKeysResponse, error) {
+		return c.BackendAPIClient.ListDimensionKeys(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) ListDimensionValues(ctx context.Context, in *v5.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v5.ListDimensionValuesResponse, error) {
+	return dedup(ctx, &c.group, "ListDimensionValues", in, func(ctx context.Context, in *v5.ListDimensionValuesRequest) (*v5.ListDimensionValuesResponse, error) {
+		return c.BackendAPIClient.ListDimensionValues(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) ListMetrics(ctx context.Context, in *v5.ListMetricsRequest, opts ...grpc.CallOption) (*v5.ListMetricsResponse, error) {
+	return dedup(ctx, &c.group, "ListMetrics", in, func(ctx context.Context, in *v5.ListMetricsRequest) (*v5.ListMetricsResponse, error) {
+		return c.BackendAPIClient.ListMetrics(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) GetQueryOptions(ctx context.Context, in *v5.GetOptionsRequest, opts ...grpc.CallOption) (*v5.GetOptionsResponse, error) {
+	return dedup(ctx, &c.group, "GetQueryOptions", in, func(ctx context.Context, in *v5.GetOptionsRequest) (*v5.GetOptionsResponse, error) {
+		return c.BackendAPIClient.GetQueryOptions(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) GetMetricValue(ctx context.Context, in *v5.GetMetricValueRequest, opts ...grpc.CallOption) (*v5.GetMetricValueResponse, error) {
+	return dedup(ctx, &c.group, "GetMetricValue", in, func(ctx context.Context, in *v5.GetMetricValueRequest) (*v5.GetMetricValueResponse, error) {
+		return c.BackendAPIClient.GetMetricValue(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) GetMetricHistory(ctx context.Context, in *v5.GetMetricHistoryRequest, opts ...grpc.CallOption) (*v5.GetMetricHistoryResponse, error) {
+	return dedup(ctx, &c.group, "GetMetricHistory", in, func(ctx context.Context, in *v5.GetMetricHistoryRequest) (*v5.GetMetricHistoryResponse, error) {
+		return c.BackendAPIClient.GetMetricHistory(ctx, in, opts...)
+	})
+}
+
+func (c *dedupClient) GetMetricAggregate(ctx context.Context, in *v5.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v5.GetMetricAggregateResponse, error) {
+	return dedup(ctx, &c.group, "GetMetricAggregate", in, func(ctx context.Context, in *v5.GetMetricAggregateRequest) (*v5.GetMetricAggregateResponse, error) {
+		return c.BackendAPIClient.GetMetricAggregate(ctx, in, opts...)
+	})
+}
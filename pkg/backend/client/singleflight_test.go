@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type blockingClient struct {
+	BackendAPIClient
+	calls   atomic.Int32
+	release chan struct{}
+	started chan struct{}
+	aborted chan struct{}
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{
+		release: make(chan struct{}),
+		started: make(chan struct{}, 10),
+		aborted: make(chan struct{}, 10),
+	}
+}
+
+func (c *blockingClient) GetMetricValue(ctx context.Context, in *v5.GetMetricValueRequest, opts ...grpc.CallOption) (*v5.GetMetricValueResponse, error) {
+	c.calls.Add(1)
+	c.started <- struct{}{}
+	select {
+	case <-c.release:
+		return &v5.GetMetricValueResponse{
+			Frames: []*v5.GetMetricValueResponse_Frame{{Metric: in.Metrics[0]}},
+		}, nil
+	case <-ctx.Done():
+		c.aborted <- struct{}{}
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+}
+
+func TestDedupClient(t *testing.T) {
+	req := &v5.GetMetricValueRequest{Metrics: []string{"foo"}}
+
+	t.Run("identical concurrent requests share a single call", func(t *testing.T) {
+		m := newBlockingClient()
+		sut := newDedupClient(m)
+
+		var wg sync.WaitGroup
+		results := make([]*v5.GetMetricValueResponse, 5)
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				res, err := sut.GetMetricValue(context.Background(), req)
+				assert.NoError(t, err)
+				results[i] = res
+			}(i)
+		}
+		<-m.started
+		// give the other callers the opportunity to join the call
+		time.Sleep(50 * time.Millisecond)
+		close(m.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), m.calls.Load())
+		for _, res := range results {
+			assert.Equal(t, "foo", res.Frames[0].Metric)
+		}
+		t.Run("every caller gets its own copy of the response", func(t *testing.T) {
+			assert.NotSame(t, results[0], results[1])
+		})
+	})
+
+	t.Run("different requests are not shared", func(t *testing.T) {
+		m := newBlockingClient()
+		close(m.release)
+		sut := newDedupClient(m)
+
+		_, err := sut.GetMetricValue(context.Background(), req)
+		assert.NoError(t, err)
+		_, err = sut.GetMetricValue(context.Background(), &v5.GetMetricValueRequest{Metrics: []string{"bar"}})
+		assert.NoError(t, err)
+
+		assert.Equal(t, int32(2), m.calls.Load())
+	})
+
+	t.Run("a canceled caller does not abort the other callers", func(t *testing.T) {
+		m := newBlockingClient()
+		sut := newDedupClient(m)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			_, err := sut.GetMetricValue(ctx, req)
+			canceled <- err
+		}()
+		<-m.started
+
+		done := make(chan error)
+		go func() {
+			_, err := sut.GetMetricValue(context.Background(), req)
+			done <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		cancel()
+		assert.Equal(t, codes.Canceled, status.Code(<-canceled))
+
+		close(m.release)
+		assert.NoError(t, <-done)
+		assert.Equal(t, int32(1), m.calls.Load())
+	})
+
+	t.Run("the deadline of the first caller does not abort a caller with a later deadline", func(t *testing.T) {
+		m := newBlockingClient()
+		sut := newDedupClient(m)
+
+		short, cancelShort := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancelShort()
+		expired := make(chan error)
+		go func() {
+			_, err := sut.GetMetricValue(short, req)
+			expired <- err
+		}()
+		<-m.started
+
+		long, cancelLong := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelLong()
+		done := make(chan error)
+		go func() {
+			_, err := sut.GetMetricValue(long, req)
+			done <- err
+		}()
+
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(<-expired))
+		time.Sleep(50 * time.Millisecond)
+		close(m.release)
+		assert.NoError(t, <-done)
+		assert.Equal(t, int32(1), m.calls.Load())
+	})
+
+	t.Run("the call is aborted when all callers are canceled", func(t *testing.T) {
+		m := newBlockingClient()
+		sut := newDedupClient(m)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		res := make(chan error)
+		go func() {
+			_, err := sut.GetMetricValue(ctx, req)
+			res <- err
+		}()
+		<-m.started
+		cancel()
+
+		assert.Equal(t, codes.Canceled, status.Code(<-res))
+		select {
+		case <-m.aborted:
+		case <-time.After(time.Second):
+			t.Fatal("expected the backend call to be aborted")
+		}
+	})
+}
@@ -4,12 +4,13 @@ import (
 	"encoding/json"
 	"fmt"
 
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 )
 
 type BackendAPIClient interface {
-	v3.GrafanaQueryAPIClient
+	v5.GrafanaQueryAPIClient
 	Dispose()
 }
 
@@ -18,6 +19,18 @@ type BackendAPIDatasourceSettings struct {
 	Endpoint   string `json:"endpoint"`
 	APIKey     string `json:"-"`
 	MaxRetries uint   `json:"max_retries"`
+
+	// QueryLimits are the limits for queries; a query can only lower these limits.
+	// A query which exceeds these limits returns a truncated result.
+	QueryLimits models.QueryLimits `json:"query_limits"`
+	// HardLimits can not be overridden by a query; a query which exceeds these limits fails.
+	HardLimits models.QueryLimits `json:"hard_limits"`
+	// MaxFanOut is the max. number of series a query with wildcard or multi-valued dimensions expands to
+	MaxFanOut int `json:"max_fan_out"`
+	// NamingStrategy names the series of queries without a display name or naming strategy
+	NamingStrategy models.NamingStrategy `json:"naming_strategy"`
+	// MetricCatalogTTL is the number of seconds after which the metric catalog of a dimension set is refreshed
+	MetricCatalogTTL int `json:"metric_catalog_ttl"`
 }
 
 func (s *BackendAPIDatasourceSettings) Load(config backend.DataSourceInstanceSettings) error {
@@ -0,0 +1,375 @@
+package v3
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	"github.com/samber/lo"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCalendarIntervals is the max. number of calendar intervals which are retrieved for a single aggregate request
+const maxCalendarIntervals = 1000
+
+// maxCalendarIntervalPages is the max. number of pages of the server which are retrieved for a single calendar interval
+const maxCalendarIntervalPages = 100
+
+type adapter struct {
+	v3Client v3.GrafanaQueryAPIClient
+}
+
+// convert converts a message to the equivalent message of the other API version. The messages of v3 and v4 share
+// their field numbers, so the fields which are unknown to the target version are discarded.
+func convert[T proto.Message](in proto.Message, out T) (T, error) {
+	b, err := proto.Marshal(in)
+	if err != nil {
+		return out, status.Errorf(codes.Internal, "could not convert %s: %s", in.ProtoReflect().Descriptor().Name(), err)
+	}
+	if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, out); err != nil {
+		return out, status.Errorf(codes.Internal, "could not convert %s: %s", in.ProtoReflect().Descriptor().Name(), err)
+	}
+	return out, nil
+}
+
+// checkDimensions returns an Unimplemented error if a dimension is not compared by equality; a v3 backend does not
+// support dimension operators
+func checkDimensions(dimensions []*v4.Dimension) error {
+	for _, d := range dimensions {
+		if d.Operator != v4.DimensionOperator_DIMENSION_OPERATOR_EQUAL {
+			return status.Errorf(codes.Unimplemented, "dimension operator %s is not supported by the backend", d.Operator)
+		}
+	}
+	return nil
+}
+
+// Returns a list of all available dimensions
+func (adapter *adapter) ListDimensionKeys(ctx context.Context, in *v4.ListDimensionKeysRequest, opts ...grpc.CallOption) (*v4.ListDimensionKeysResponse, error) {
+	if err := checkDimensions(in.SelectedDimensions); err != nil {
+		return nil, err
+	}
+	req, err := convert(in, &v3.ListDimensionKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.ListDimensionKeys(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.ListDimensionKeysResponse{})
+}
+
+// Returns a list of all dimension values for a certain dimension
+func (adapter *adapter) ListDimensionValues(ctx context.Context, in *v4.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v4.ListDimensionValuesResponse, error) {
+	if err := checkDimensions(in.SelectedDimensions); err != nil {
+		return nil, err
+	}
+	req, err := convert(in, &v3.ListDimensionValuesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.ListDimensionValues(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.ListDimensionValuesResponse{})
+}
+
+// Returns all metrics from the system
+func (adapter *adapter) ListMetrics(ctx context.Context, in *v4.ListMetricsRequest, opts ...grpc.CallOption) (*v4.ListMetricsResponse, error) {
+	if err := checkDimensions(in.Dimensions); err != nil {
+		return nil, err
+	}
+	req, err := convert(in, &v3.ListMetricsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.ListMetrics(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.ListMetricsResponse{})
+}
+
+// Gets the options for the specified query type
+func (adapter *adapter) GetQueryOptions(ctx context.Context, in *v4.GetOptionsRequest, opts ...grpc.CallOption) (*v4.GetOptionsResponse, error) {
+	req, err := convert(in, &v3.GetOptionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.GetQueryOptions(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.GetOptionsResponse{})
+}
+
+// Gets the last known value for one or more metrics
+func (adapter *adapter) GetMetricValue(ctx context.Context, in *v4.GetMetricValueRequest, opts ...grpc.CallOption) (*v4.GetMetricValueResponse, error) {
+	if err := checkDimensions(in.Dimensions); err != nil {
+		return nil, err
+	}
+	req, err := convert(in, &v3.GetMetricValueRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.GetMetricValue(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.GetMetricValueResponse{})
+}
+
+// Gets the history for one or more metrics
+func (adapter *adapter) GetMetricHistory(ctx context.Context, in *v4.GetMetricHistoryRequest, opts ...grpc.CallOption) (*v4.GetMetricHistoryResponse, error) {
+	if err := checkDimensions(in.Dimensions); err != nil {
+		return nil, err
+	}
+	req, err := convert(in, &v3.GetMetricHistoryRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.GetMetricHistory(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.GetMetricHistoryResponse{})
+}
+
+// Gets the aggregates for one or more metrics
+func (adapter *adapter) GetMetricAggregate(ctx context.Context, in *v4.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v4.GetMetricAggregateResponse, error) {
+	if err := checkDimensions(in.Dimensions); err != nil {
+		return nil, err
+	}
+	if in.CalendarInterval != v4.CalendarInterval_CALENDAR_INTERVAL_NONE {
+		return adapter.getCalendarAggregate(ctx, in, opts...)
+	}
+	req, err := convert(in, &v3.GetMetricAggregateRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v3Client.GetMetricAggregate(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v4.GetMetricAggregateResponse{})
+}
+
+func toCalendarInterval(interval v4.CalendarInterval) models.CalendarInterval {
+	switch interval {
+	case v4.CalendarInterval_CALENDAR_INTERVAL_DAY:
+		return models.CalendarIntervalDay
+	case v4.CalendarInterval_CALENDAR_INTERVAL_WEEK:
+		return models.CalendarIntervalWeek
+	case v4.CalendarInterval_CALENDAR_INTERVAL_MONTH:
+		return models.CalendarIntervalMonth
+	case v4.CalendarInterval_CALENDAR_INTERVAL_QUARTER:
+		return models.CalendarIntervalQuarter
+	default:
+		return models.CalendarIntervalNone
+	}
+}
+
+// getCalendarAggregate aligns the aggregates to calendar intervals for a server which does not support them. It
+// requests the aggregate of each interval separately and stamps it with the start of the interval, so the length
+// of an interval is retained across DST transitions and months of different length. Every interval is a page of the
+// result (see calendarToken), so the query limits of the plugin apply to the intervals as well.
+// A server which splits an interval into several aggregates (e.g. a server which aggregates by UTC day) returns more
+// than one row for an interval; these rows are combined into the aggregate of the interval (see combineRows).
+func (adapter *adapter) getCalendarAggregate(ctx context.Context, in *v4.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v4.GetMetricAggregateResponse, error) {
+	loc, err := time.LoadLocation(in.TimeZone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid time zone %q", in.TimeZone)
+	}
+	interval := toCalendarInterval(in.CalendarInterval)
+	from, to := in.StartDate.AsTime(), in.EndDate.AsTime()
+
+	starts := interval.Intervals(from, to, loc)
+	if len(starts) > maxCalendarIntervals {
+		return nil, status.Errorf(codes.InvalidArgument, "the time range contains %d %s intervals; the max. is %d", len(starts), interval, maxCalendarIntervals)
+	}
+	index, err := parseCalendarToken(in.StartingToken)
+	if err != nil {
+		return nil, err
+	}
+	if index >= len(starts) {
+		return &v4.GetMetricAggregateResponse{}, nil
+	}
+	start := starts[index]
+	end := interval.Next(start)
+
+	req, err := convert(in, &v3.GetMetricAggregateRequest{})
+	if err != nil {
+		return nil, err
+	}
+	// the first and last interval are restricted to the time range of the request
+	req.StartDate = timestamppb.New(latest(start, from))
+	req.EndDate = timestamppb.New(earliest(end, to))
+	req.IntervalMs = end.Sub(start).Milliseconds()
+	req.StartingToken = ""
+
+	// the rows of the frames of a metric of all pages of the interval
+	var frames []*v4.Frame
+	for pages := 0; ; pages++ {
+		if pages == maxCalendarIntervalPages {
+			return nil, status.Errorf(codes.ResourceExhausted, "the backend returned more than %d pages for the %s starting at %s", maxCalendarIntervalPages, interval, start.Format(time.RFC3339))
+		}
+		page, err := adapter.v3Client.GetMetricAggregate(ctx, req, opts...)
+		if err != nil {
+			return nil, err
+		}
+		converted, err := convert(page, &v4.GetMetricAggregateResponse{})
+		if err != nil {
+			return nil, err
+		}
+		frames = appendRows(frames, converted.Frames)
+		if page.NextToken == "" {
+			break
+		}
+		req.StartingToken = page.NextToken
+	}
+
+	res := &v4.GetMetricAggregateResponse{}
+	for _, frame := range frames {
+		if len(frame.Timestamps) > 0 {
+			res.Frames = append(res.Frames, combineRows(frame, start, req.EndDate.AsTime()))
+		}
+	}
+	if index+1 < len(starts) {
+		res.NextToken = calendarToken(index + 1)
+	}
+	return res, nil
+}
+
+// calendarToken returns the pagination token of a calendar aggregate, which is the index of the next interval
+func calendarToken(index int) string {
+	return strconv.Itoa(index)
+}
+
+// parseCalendarToken returns the index of the interval of a calendarToken
+func parseCalendarToken(s string) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid starting token %q", s)
+	}
+	return i, nil
+}
+
+// appendRows appends the rows of the frames of a page to the frames of the same metric
+func appendRows(frames []*v4.Frame, page []*v4.Frame) []*v4.Frame {
+	for _, frame := range page {
+		existing, found := lo.Find(frames, func(f *v4.Frame) bool { return f.Metric == frame.Metric })
+		if !found {
+			frames = append(frames, proto.Clone(frame).(*v4.Frame))
+			continue
+		}
+		existing.Timestamps = append(existing.Timestamps, frame.Timestamps...)
+		for _, fld := range frame.Fields {
+			target, found := lo.Find(existing.Fields, func(f *v4.Field) bool { return f.Name == fld.Name })
+			if !found {
+				continue
+			}
+			target.Values = append(target.Values, fld.Values...)
+			target.StringValues = append(target.StringValues, fld.StringValues...)
+		}
+	}
+	return frames
+}
+
+// combineRows returns the frame with a single row, stamped with the start of the interval, which combines the rows of
+// the server. The aggregation of a field is derived from its name: the rows of min and max fields are combined with
+// min and max, the rows of sum and count fields are added, and the other fields are averaged, weighted by the period
+// which is covered by each row. Missing values (NaN or an empty string) are ignored; a string field gets its last value.
+func combineRows(frame *v4.Frame, start, end time.Time) *v4.Frame {
+	res := proto.Clone(frame).(*v4.Frame)
+	res.Timestamps = []*timestamppb.Timestamp{timestamppb.New(start)}
+	if len(frame.Timestamps) == 1 {
+		return res
+	}
+
+	// the weight of a row is the period until the next row (in time), or until the end of the interval
+	rows := lo.Range(len(frame.Timestamps))
+	sort.SliceStable(rows, func(i, j int) bool {
+		return frame.Timestamps[rows[i]].AsTime().Before(frame.Timestamps[rows[j]].AsTime())
+	})
+	weights := make([]float64, len(frame.Timestamps))
+	for i, row := range rows {
+		next := end
+		if i+1 < len(rows) {
+			next = frame.Timestamps[rows[i+1]].AsTime()
+		}
+		weights[row] = math.Max(0, float64(next.Sub(latest(frame.Timestamps[row].AsTime(), start))))
+	}
+
+	for _, fld := range res.Fields {
+		if len(fld.StringValues) > 0 {
+			last := ""
+			for _, row := range rows {
+				if row < len(fld.StringValues) && fld.StringValues[row] != "" {
+					last = fld.StringValues[row]
+				}
+			}
+			fld.StringValues = []string{last}
+			continue
+		}
+		var values, valueWeights []float64
+		for _, row := range rows {
+			if row < len(fld.Values) && !math.IsNaN(fld.Values[row]) {
+				values = append(values, fld.Values[row])
+				valueWeights = append(valueWeights, weights[row])
+			}
+		}
+		fld.Values = []float64{combine(fld.Name, values, valueWeights)}
+	}
+	return res
+}
+
+// combine returns the aggregate of the values of an aggregate field (see combineRows)
+func combine(name string, values, weights []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	switch strings.ToLower(name) {
+	case "min":
+		return lo.Min(values)
+	case "max":
+		return lo.Max(values)
+	case "sum", "count":
+		return lo.Sum(values)
+	}
+	total, weight := 0.0, 0.0
+	for i, v := range values {
+		total += v * weights[i]
+		weight += weights[i]
+	}
+	if weight <= 0 {
+		return lo.Sum(values) / float64(len(values))
+	}
+	return total / weight
+}
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earliest(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,230 @@
+package v3
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type v3Mock struct {
+	v3.GrafanaQueryAPIClient
+	mock.Mock
+}
+
+func (v *v3Mock) GetMetricHistory(ctx context.Context, in *v3.GetMetricHistoryRequest, opts ...grpc.CallOption) (*v3.GetMetricHistoryResponse, error) {
+	args := v.Called(ctx, in)
+	if v, ok := args.Get(0).(*v3.GetMetricHistoryResponse); ok {
+		return v, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// GetMetricAggregate returns the length of the requested interval (in hours) as the value of each aggregate
+func (v *v3Mock) GetMetricAggregate(ctx context.Context, in *v3.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v3.GetMetricAggregateResponse, error) {
+	v.Called(ctx, in)
+	return &v3.GetMetricAggregateResponse{
+		Frames: []*v3.Frame{
+			{
+				Metric:     "output",
+				Timestamps: []*timestamppb.Timestamp{in.StartDate},
+				Fields:     []*v3.Field{{Name: "sum", Values: []float64{float64(in.IntervalMs) / float64(time.Hour.Milliseconds())}}},
+			},
+		},
+	}, nil
+}
+
+func TestAdapter_GetMetricHistory(t *testing.T) {
+	m := &v3Mock{}
+	m.On("GetMetricHistory", mock.Anything, mock.MatchedBy(func(in *v3.GetMetricHistoryRequest) bool {
+		return in.MaxItems == 10 && in.TimeOrdering == v3.TimeOrdering_DESCENDING && in.Metrics[0] == "output"
+	})).Return(&v3.GetMetricHistoryResponse{
+		Frames:    []*v3.Frame{{Metric: "output", Fields: []*v3.Field{{Name: "value", Values: []float64{1}}}}},
+		NextToken: "next",
+	}, nil)
+
+	res, err := Wrap(m).GetMetricHistory(context.TODO(), &v4.GetMetricHistoryRequest{
+		Metrics:      []string{"output"},
+		MaxItems:     10,
+		TimeOrdering: v4.TimeOrdering_DESCENDING,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "next", res.NextToken)
+	assert.Equal(t, []float64{1}, res.Frames[0].Fields[0].Values)
+}
+
+// allPages returns the frames of all pages of an aggregate request
+func allPages(client v4.GrafanaQueryAPIClient, req *v4.GetMetricAggregateRequest) (*v4.GetMetricAggregateResponse, error) {
+	res := &v4.GetMetricAggregateResponse{}
+	for {
+		page, err := client.GetMetricAggregate(context.TODO(), req)
+		if err != nil {
+			return nil, err
+		}
+		res.Frames = append(res.Frames, page.Frames...)
+		if page.NextToken == "" {
+			return res, nil
+		}
+		req.StartingToken = page.NextToken
+	}
+}
+
+// splitDayMock is a backend which aggregates by UTC day; the aggregate of each UTC day is a separate page
+type splitDayMock struct {
+	v3Mock
+}
+
+func (v *splitDayMock) GetMetricAggregate(ctx context.Context, in *v3.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v3.GetMetricAggregateResponse, error) {
+	v.Called(ctx, in)
+	start := in.StartDate.AsTime()
+	if in.StartingToken == "" {
+		return &v3.GetMetricAggregateResponse{
+			Frames: []*v3.Frame{{
+				Metric:     "output",
+				Timestamps: []*timestamppb.Timestamp{in.StartDate},
+				Fields: []*v3.Field{
+					{Name: "sum", Values: []float64{2}},
+					{Name: "avg", Values: []float64{1}},
+					{Name: "max", Values: []float64{5}},
+					{Name: "state", StringValues: []string{"on"}},
+				},
+			}},
+			NextToken: "next",
+		}, nil
+	}
+	midnight := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, time.UTC)
+	return &v3.GetMetricAggregateResponse{
+		Frames: []*v3.Frame{{
+			Metric:     "output",
+			Timestamps: []*timestamppb.Timestamp{timestamppb.New(midnight)},
+			Fields: []*v3.Field{
+				{Name: "sum", Values: []float64{22}},
+				{Name: "avg", Values: []float64{2}},
+				{Name: "max", Values: []float64{math.NaN()}},
+				{Name: "state", StringValues: []string{""}},
+			},
+		}},
+	}, nil
+}
+
+func TestAdapter_GetMetricAggregate_CalendarInterval(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	t.Run("should align the aggregates to the local days across a DST transition", func(t *testing.T) {
+		m := &v3Mock{}
+		m.On("GetMetricAggregate", mock.Anything, mock.Anything).Return()
+
+		// the clocks are moved forward in the night of 31 march 2024
+		res, err := allPages(Wrap(m), &v4.GetMetricAggregateRequest{
+			Metrics:          []string{"output"},
+			StartDate:        timestamppb.New(time.Date(2024, 3, 30, 0, 0, 0, 0, amsterdam)),
+			EndDate:          timestamppb.New(time.Date(2024, 4, 2, 0, 0, 0, 0, amsterdam)),
+			CalendarInterval: v4.CalendarInterval_CALENDAR_INTERVAL_DAY,
+			TimeZone:         "Europe/Amsterdam",
+		})
+		assert.NoError(t, err)
+		m.AssertNumberOfCalls(t, "GetMetricAggregate", 3)
+
+		if assert.Len(t, res.Frames, 3) {
+			for i, hours := range []float64{24, 23, 24} {
+				assert.Equal(t, time.Date(2024, 3, 30+i, 0, 0, 0, 0, amsterdam).Unix(), res.Frames[i].Timestamps[0].AsTime().Unix())
+				assert.Equal(t, []float64{hours}, res.Frames[i].Fields[0].Values)
+			}
+		}
+	})
+
+	t.Run("should restrict the first and last interval to the time range", func(t *testing.T) {
+		m := &v3Mock{}
+		m.On("GetMetricAggregate", mock.Anything, mock.Anything).Return()
+
+		from := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+		res, err := allPages(Wrap(m), &v4.GetMetricAggregateRequest{
+			StartDate:        timestamppb.New(from),
+			EndDate:          timestamppb.New(to),
+			CalendarInterval: v4.CalendarInterval_CALENDAR_INTERVAL_MONTH,
+		})
+		assert.NoError(t, err)
+		if assert.Len(t, res.Frames, 3) {
+			assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), res.Frames[0].Timestamps[0].AsTime())
+			assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), res.Frames[2].Timestamps[0].AsTime())
+		}
+
+		first := m.Calls[0].Arguments.Get(1).(*v3.GetMetricAggregateRequest)
+		assert.Equal(t, from, first.StartDate.AsTime())
+		last := m.Calls[2].Arguments.Get(1).(*v3.GetMetricAggregateRequest)
+		assert.Equal(t, to, last.EndDate.AsTime())
+	})
+
+	t.Run("should return an aggregate per page", func(t *testing.T) {
+		m := &v3Mock{}
+		m.On("GetMetricAggregate", mock.Anything, mock.Anything).Return()
+
+		req := &v4.GetMetricAggregateRequest{
+			StartDate:        timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			EndDate:          timestamppb.New(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)),
+			CalendarInterval: v4.CalendarInterval_CALENDAR_INTERVAL_DAY,
+		}
+		res, err := Wrap(m).GetMetricAggregate(context.TODO(), req)
+		assert.NoError(t, err)
+		assert.Len(t, res.Frames, 1)
+		assert.NotEmpty(t, res.NextToken)
+		m.AssertNumberOfCalls(t, "GetMetricAggregate", 1)
+	})
+
+	t.Run("should combine the aggregates of a backend which splits an interval", func(t *testing.T) {
+		m := &splitDayMock{}
+		m.On("GetMetricAggregate", mock.Anything, mock.Anything).Return()
+
+		res, err := Wrap(m).GetMetricAggregate(context.TODO(), &v4.GetMetricAggregateRequest{
+			StartDate:        timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, amsterdam)),
+			EndDate:          timestamppb.New(time.Date(2024, 1, 2, 0, 0, 0, 0, amsterdam)),
+			CalendarInterval: v4.CalendarInterval_CALENDAR_INTERVAL_DAY,
+			TimeZone:         "Europe/Amsterdam",
+		})
+		assert.NoError(t, err)
+		m.AssertNumberOfCalls(t, "GetMetricAggregate", 2)
+		assert.Empty(t, res.NextToken)
+		if assert.Len(t, res.Frames, 1) {
+			frame := res.Frames[0]
+			assert.Equal(t, []time.Time{time.Date(2024, 1, 1, 0, 0, 0, 0, amsterdam).UTC()}, []time.Time{frame.Timestamps[0].AsTime()})
+			assert.Equal(t, []float64{24}, frame.Fields[0].Values, "the sums are added")
+			assert.InDelta(t, 47.0/24, frame.Fields[1].Values[0], 1e-9, "the averages are weighted by the period of each row")
+			assert.Equal(t, []float64{5}, frame.Fields[2].Values, "missing values are ignored")
+			assert.Equal(t, []string{"on"}, frame.Fields[3].StringValues)
+		}
+	})
+
+	t.Run("should reject an unknown time zone", func(t *testing.T) {
+		_, err := Wrap(&v3Mock{}).GetMetricAggregate(context.TODO(), &v4.GetMetricAggregateRequest{
+			CalendarInterval: v4.CalendarInterval_CALENDAR_INTERVAL_DAY,
+			TimeZone:         "Mars/Olympus_Mons",
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestAdapter_DimensionOperators(t *testing.T) {
+	m := &v3Mock{}
+	_, err := Wrap(m).GetMetricHistory(context.TODO(), &v4.GetMetricHistoryRequest{
+		Metrics: []string{"output"},
+		Dimensions: []*v4.Dimension{
+			{Key: "site", Value: "ams"},
+			{Key: "machine", Value: "press-1", Operator: v4.DimensionOperator_DIMENSION_OPERATOR_NOT_EQUAL},
+		},
+	})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	m.AssertNotCalled(t, "GetMetricHistory", mock.Anything, mock.Anything)
+}
@@ -1,10 +1,16 @@
-package v2
+package v3
 
 import (
 	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
 	"google.golang.org/grpc"
 )
 
-func NewClient(conn *grpc.ClientConn) (v3.GrafanaQueryAPIClient, error) {
-	return v3.NewGrafanaQueryAPIClient(conn), nil
+func NewClient(conn *grpc.ClientConn) (v4.GrafanaQueryAPIClient, error) {
+	return Wrap(v3.NewGrafanaQueryAPIClient(conn)), nil
+}
+
+// Wrap returns a v4 client for a client of the v3 API, e.g. the adapter of an older API version
+func Wrap(client v3.GrafanaQueryAPIClient) v4.GrafanaQueryAPIClient {
+	return &adapter{v3Client: client}
 }
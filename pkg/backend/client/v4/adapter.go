@@ -0,0 +1,167 @@
+package v4
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type adapter struct {
+	v4Client v4.GrafanaQueryAPIClient
+}
+
+// convert converts a message to the equivalent message of the other API version. The messages of v4 and v5 share
+// their field numbers, so the fields which are unknown to the target version are discarded.
+func convert[T proto.Message](in proto.Message, out T) (T, error) {
+	b, err := proto.Marshal(in)
+	if err != nil {
+		return out, status.Errorf(codes.Internal, "could not convert %s: %s", in.ProtoReflect().Descriptor().Name(), err)
+	}
+	if err := (proto.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(b, out); err != nil {
+		return out, status.Errorf(codes.Internal, "could not convert %s: %s", in.ProtoReflect().Descriptor().Name(), err)
+	}
+	return out, nil
+}
+
+// withoutOptions returns a copy of a query request without its options; the typed options of v5 do not share their
+// encoding with the string options of v4
+func withoutOptions(in proto.Message) proto.Message {
+	out := proto.Clone(in)
+	m := out.ProtoReflect()
+	m.Clear(m.Descriptor().Fields().ByName("options"))
+	return out
+}
+
+// flattenOptions converts typed options to the string options of v4
+func flattenOptions(options map[string]*v5.OptionValue) map[string]string {
+	if len(options) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(options))
+	for k, v := range options {
+		res[k] = flattenOption(v)
+	}
+	return res
+}
+
+func flattenOption(v *v5.OptionValue) string {
+	switch v.GetValue().(type) {
+	case *v5.OptionValue_Text:
+		return v.GetText()
+	case *v5.OptionValue_Number:
+		return strconv.FormatFloat(v.GetNumber(), 'f', -1, 64)
+	case *v5.OptionValue_Boolean:
+		return strconv.FormatBool(v.GetBoolean())
+	case *v5.OptionValue_Duration:
+		return v.GetDuration().AsDuration().String()
+	case *v5.OptionValue_DateRange:
+		// an ISO 8601 time interval
+		return v.GetDateRange().GetFrom().AsTime().Format(time.RFC3339) + "/" + v.GetDateRange().GetTo().AsTime().Format(time.RFC3339)
+	case *v5.OptionValue_Values:
+		return strings.Join(v.GetValues().GetValues(), ",")
+	default:
+		return ""
+	}
+}
+
+// Returns a list of all available dimensions
+func (adapter *adapter) ListDimensionKeys(ctx context.Context, in *v5.ListDimensionKeysRequest, opts ...grpc.CallOption) (*v5.ListDimensionKeysResponse, error) {
+	req, err := convert(in, &v4.ListDimensionKeysRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v4Client.ListDimensionKeys(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.ListDimensionKeysResponse{})
+}
+
+// Returns a list of all dimension values for a certain dimension
+func (adapter *adapter) ListDimensionValues(ctx context.Context, in *v5.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v5.ListDimensionValuesResponse, error) {
+	req, err := convert(in, &v4.ListDimensionValuesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v4Client.ListDimensionValues(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.ListDimensionValuesResponse{})
+}
+
+// Returns all metrics from the system
+func (adapter *adapter) ListMetrics(ctx context.Context, in *v5.ListMetricsRequest, opts ...grpc.CallOption) (*v5.ListMetricsResponse, error) {
+	req, err := convert(in, &v4.ListMetricsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v4Client.ListMetrics(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.ListMetricsResponse{})
+}
+
+// Gets the options for the specified query type
+func (adapter *adapter) GetQueryOptions(ctx context.Context, in *v5.GetOptionsRequest, opts ...grpc.CallOption) (*v5.GetOptionsResponse, error) {
+	req, err := convert(in, &v4.GetOptionsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	res, err := adapter.v4Client.GetQueryOptions(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.GetOptionsResponse{})
+}
+
+// Gets the last known value for one or more metrics
+func (adapter *adapter) GetMetricValue(ctx context.Context, in *v5.GetMetricValueRequest, opts ...grpc.CallOption) (*v5.GetMetricValueResponse, error) {
+	req, err := convert(withoutOptions(in), &v4.GetMetricValueRequest{})
+	if err != nil {
+		return nil, err
+	}
+	req.Options = flattenOptions(in.Options)
+	res, err := adapter.v4Client.GetMetricValue(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.GetMetricValueResponse{})
+}
+
+// Gets the history for one or more metrics
+func (adapter *adapter) GetMetricHistory(ctx context.Context, in *v5.GetMetricHistoryRequest, opts ...grpc.CallOption) (*v5.GetMetricHistoryResponse, error) {
+	req, err := convert(withoutOptions(in), &v4.GetMetricHistoryRequest{})
+	if err != nil {
+		return nil, err
+	}
+	req.Options = flattenOptions(in.Options)
+	res, err := adapter.v4Client.GetMetricHistory(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.GetMetricHistoryResponse{})
+}
+
+// Gets the aggregates for one or more metrics
+func (adapter *adapter) GetMetricAggregate(ctx context.Context, in *v5.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v5.GetMetricAggregateResponse, error) {
+	req, err := convert(withoutOptions(in), &v4.GetMetricAggregateRequest{})
+	if err != nil {
+		return nil, err
+	}
+	req.Options = flattenOptions(in.Options)
+	res, err := adapter.v4Client.GetMetricAggregate(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return convert(res, &v5.GetMetricAggregateResponse{})
+}
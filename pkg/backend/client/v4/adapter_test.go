@@ -0,0 +1,93 @@
+package v4
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type v4Mock struct {
+	v4.GrafanaQueryAPIClient
+	mock.Mock
+}
+
+func (v *v4Mock) GetMetricAggregate(ctx context.Context, in *v4.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v4.GetMetricAggregateResponse, error) {
+	args := v.Called(ctx, in)
+	if v, ok := args.Get(0).(*v4.GetMetricAggregateResponse); ok {
+		return v, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (v *v4Mock) GetQueryOptions(ctx context.Context, in *v4.GetOptionsRequest, opts ...grpc.CallOption) (*v4.GetOptionsResponse, error) {
+	args := v.Called(ctx, in)
+	if v, ok := args.Get(0).(*v4.GetOptionsResponse); ok {
+		return v, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func TestAdapter_GetMetricAggregate(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := &v4Mock{}
+	m.On("GetMetricAggregate", mock.Anything, mock.MatchedBy(func(in *v4.GetMetricAggregateRequest) bool {
+		return in.IntervalMs == 60_000 && in.Metrics[0] == "output" && assert.ObjectsAreEqual(map[string]string{
+			"aggregate":   "avg",
+			"percentile":  "99.5",
+			"interpolate": "false",
+			"window":      "1h30m0s",
+			"period":      "2023-01-01T00:00:00Z/2023-01-02T00:00:00Z",
+			"shifts":      "a,b",
+		}, in.Options)
+	})).Return(&v4.GetMetricAggregateResponse{
+		Frames:    []*v4.Frame{{Metric: "output", Fields: []*v4.Field{{Name: "avg", Values: []float64{1}}}}},
+		NextToken: "next",
+	}, nil)
+
+	res, err := Wrap(m).GetMetricAggregate(context.TODO(), &v5.GetMetricAggregateRequest{
+		Metrics:    []string{"output"},
+		IntervalMs: 60_000,
+		Options: map[string]*v5.OptionValue{
+			"aggregate":   {Value: &v5.OptionValue_Text{Text: "avg"}},
+			"percentile":  {Value: &v5.OptionValue_Number{Number: 99.5}},
+			"interpolate": {Value: &v5.OptionValue_Boolean{Boolean: false}},
+			"window":      {Value: &v5.OptionValue_Duration{Duration: durationpb.New(90 * time.Minute)}},
+			"period":      {Value: &v5.OptionValue_DateRange{DateRange: &v5.DateRange{From: timestamppb.New(from), To: timestamppb.New(from.AddDate(0, 0, 1))}}},
+			"shifts":      {Value: &v5.OptionValue_Values{Values: &v5.StringList{Values: []string{"a", "b"}}}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "next", res.NextToken)
+	if assert.Len(t, res.Frames, 1) {
+		assert.Equal(t, []float64{1}, res.Frames[0].Fields[0].Values)
+	}
+	m.AssertExpectations(t)
+}
+
+func TestAdapter_GetQueryOptions(t *testing.T) {
+	m := &v4Mock{}
+	m.On("GetQueryOptions", mock.Anything, mock.MatchedBy(func(in *v4.GetOptionsRequest) bool {
+		return in.QueryType == v4.GetOptionsRequest_GetMetricAggregate && in.SelectedOptions["aggregate"] == "avg"
+	})).Return(&v4.GetOptionsResponse{
+		Options: []*v4.Option{{Id: "interpolate", Type: v4.Option_Boolean, Required: true, Label: "Interpolate"}},
+	}, nil)
+
+	res, err := Wrap(m).GetQueryOptions(context.TODO(), &v5.GetOptionsRequest{
+		QueryType:       v5.GetOptionsRequest_GetMetricAggregate,
+		SelectedOptions: map[string]string{"aggregate": "avg"},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, res.Options, 1) {
+		assert.Equal(t, v5.Option_Boolean, res.Options[0].Type)
+		assert.Equal(t, "Interpolate", res.Options[0].Label)
+		assert.True(t, res.Options[0].Required)
+	}
+}
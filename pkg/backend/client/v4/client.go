@@ -0,0 +1,16 @@
+package v4
+
+import (
+	v4 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v4"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"google.golang.org/grpc"
+)
+
+func NewClient(conn *grpc.ClientConn) (v5.GrafanaQueryAPIClient, error) {
+	return Wrap(v4.NewGrafanaQueryAPIClient(conn)), nil
+}
+
+// Wrap returns a v5 client for a client of the v4 API, e.g. the adapter of an older API version
+func Wrap(client v4.GrafanaQueryAPIClient) v5.GrafanaQueryAPIClient {
+	return &adapter{v4Client: client}
+}
@@ -1,30 +1,209 @@
 package connector
 
 import (
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	"math"
+	"sort"
+	"strings"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/samber/lo"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func appendMatchingFrames(frames map[string]*pb.Frame, newFrames []*pb.Frame) {
+// frameMerger merges the frames of consecutive pages into a single frame per metric.
+// Fields are matched by their name and labels. Values which are missing for a timestamp are padded with NaN (numeric
+// fields) or an empty string (string fields), so all fields of a frame have the same length; the framer returns these
+// values as null.
+type frameMerger struct {
+	frames map[string]*mergedFrame
+}
+
+func newFrameMerger() *frameMerger {
+	return &frameMerger{frames: map[string]*mergedFrame{}}
+}
+
+type mergedFrame struct {
+	// frame is the first frame which was received for the metric
+	frame      *pb.Frame
+	timestamps []*timestamppb.Timestamp
+	// times are the timestamps in unix nanoseconds
+	times []int64
+	// rows maps a timestamp (in unix nanoseconds) to its row
+	rows       map[int64]int
+	fields     []*mergedField
+	fieldIndex map[string]*mergedField
+}
+
+type mergedField struct {
+	field *pb.Field
+	// isString is decided by the first page which contains values of the field
+	isString     bool
+	values       []float64
+	stringValues []string
+	present      []bool
+}
+
+// fieldKey returns a key which is unique for the name and labels of a field
+func fieldKey(fld *pb.Field) string {
+	labels := lo.Map(fld.GetLabels(), func(l *pb.Label, _ int) string { return l.Key + "=" + l.Value })
+	sort.Strings(labels)
+	return fld.GetName() + "{" + strings.Join(labels, ",") + "}"
+}
+
+// append merges the frames of a page with the frames which are already received.
+func (m *frameMerger) append(newFrames []*pb.Frame) {
 	for _, frame := range newFrames {
-		// Check if the metric already exists in the frames map
-		fr, exists := frames[frame.Metric]
+		fr, exists := m.frames[frame.Metric]
+		if !exists {
+			fr = &mergedFrame{
+				frame:      frame,
+				rows:       map[int64]int{},
+				fieldIndex: map[string]*mergedField{},
+			}
+			m.frames[frame.Metric] = fr
+		} else {
+			fr.appendNotices(frame.GetMeta())
+		}
+		fr.append(frame)
+	}
+}
+
+func (fr *mergedFrame) appendNotices(meta *pb.FrameMeta) {
+	if len(meta.GetNotices()) == 0 {
+		return
+	}
+	if fr.frame.Meta == nil {
+		fr.frame.Meta = &pb.FrameMeta{}
+	}
+	for _, n := range meta.GetNotices() {
+		if !lo.ContainsBy(fr.frame.Meta.Notices, func(item *pb.FrameMeta_Notice) bool { return item.Text == n.Text }) {
+			fr.frame.Meta.Notices = append(fr.frame.Meta.Notices, n)
+		}
+	}
+}
+
+func (fr *mergedFrame) append(frame *pb.Frame) {
+	rows := make([]int, len(frame.Timestamps))
+	for i, ts := range frame.Timestamps {
+		key := ts.AsTime().UnixNano()
+		row, exists := fr.rows[key]
+		if !exists {
+			row = len(fr.timestamps)
+			fr.rows[key] = row
+			fr.timestamps = append(fr.timestamps, ts)
+			fr.times = append(fr.times, key)
+			for _, fld := range fr.fields {
+				fld.grow()
+			}
+		}
+		rows[i] = row
+	}
+
+	for _, fld := range frame.Fields {
+		key := fieldKey(fld)
+		merged, exists := fr.fieldIndex[key]
 		if !exists {
-			frames[frame.Metric] = frame
+			merged = &mergedField{field: fld}
+			for range fr.timestamps {
+				merged.grow()
+			}
+			fr.fieldIndex[key] = merged
+			fr.fields = append(fr.fields, merged)
+		}
+		merged.set(rows, fld)
+	}
+}
+
+func (f *mergedField) grow() {
+	f.stringValues = append(f.stringValues, "")
+	f.values = append(f.values, math.NaN())
+	f.present = append(f.present, false)
+}
+
+// set sets the values of a page field; values of timestamps which are already received are ignored.
+func (f *mergedField) set(rows []int, fld *pb.Field) {
+	if !lo.SomeBy(f.present, func(p bool) bool { return p }) {
+		f.isString = len(fld.StringValues) > 0
+	}
+	for i, row := range rows {
+		if f.present[row] {
+			continue
+		}
+		// a missing value of a page does not replace the value of another page
+		switch {
+		case f.isString && i < len(fld.StringValues) && fld.StringValues[i] != "":
+			f.stringValues[row] = fld.StringValues[i]
+		case !f.isString && i < len(fld.Values) && !math.IsNaN(fld.Values[i]):
+			f.values[row] = fld.Values[i]
+		default:
 			continue
 		}
+		f.present[row] = true
+	}
+}
 
-		// Iterate through each field in the current frame
-		for _, fld := range frame.Fields {
-			// Check if the field already exists in the existing frame
-			if field, found := lo.Find(fr.Fields, func(f *pb.Field) bool { return f.Name == fld.Name }); found {
-				field.Values = append(field.Values, fld.Values...)
-			} else {
-				fr.Fields = append(fr.Fields, fld)
+// reached returns whether every field of the frames of the metrics contains at least maxItems values. A metric without
+// a frame has not reached maxItems, since its values may be on a later page.
+func (m *frameMerger) reached(metrics []models.Metric, maxItems int64) bool {
+	if maxItems <= 0 || len(metrics) == 0 {
+		return false
+	}
+	for _, metric := range metrics {
+		fr, ok := m.frames[metric.MetricId]
+		if !ok || len(fr.fields) == 0 {
+			return false
+		}
+		for _, fld := range fr.fields {
+			if int64(lo.Count(fld.present, true)) < maxItems {
+				return false
 			}
 		}
+	}
+	return true
+}
+
+// result returns the merged frames; the values of each frame are sorted by time.
+func (m *frameMerger) result() []*pb.Frame {
+	return lo.MapToSlice(m.frames, func(_ string, fr *mergedFrame) *pb.Frame { return fr.build() })
+}
+
+func (fr *mergedFrame) build() *pb.Frame {
+	order := make([]int, len(fr.timestamps))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fr.times[order[i]] < fr.times[order[j]]
+	})
 
-		// Append the timestamps from the current frame
-		fr.Timestamps = append(fr.Timestamps, frame.Timestamps...)
+	res := &pb.Frame{
+		Metric:     fr.frame.Metric,
+		Meta:       fr.frame.Meta,
+		Timestamps: make([]*timestamppb.Timestamp, len(order)),
+		Fields:     make([]*pb.Field, len(fr.fields)),
+	}
+	for i, row := range order {
+		res.Timestamps[i] = fr.timestamps[row]
+	}
+	for i, f := range fr.fields {
+		fld := &pb.Field{
+			Name:   f.field.Name,
+			Labels: f.field.Labels,
+			Config: f.field.Config,
+		}
+		if f.isString {
+			fld.StringValues = make([]string, len(order))
+			for j, row := range order {
+				fld.StringValues[j] = f.stringValues[row]
+			}
+		} else {
+			fld.Values = make([]float64, len(order))
+			for j, row := range order {
+				fld.Values[j] = f.values[row]
+			}
+		}
+		res.Fields[i] = fld
 	}
+	return res
 }
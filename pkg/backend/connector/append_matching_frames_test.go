@@ -1,41 +1,213 @@
 package connector
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 )
 
-func TestAppendMatchingFrames(t *testing.T) {
-	res := []*pb.Frame{
-		{
-			Metric: "temperature", Fields: []*pb.Field{
-				{Name: "value", Values: []float64{1, 2, 3}},
-			},
-			Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Now())},
-		},
+func timestamps(seconds ...int64) []*timestamppb.Timestamp {
+	res := make([]*timestamppb.Timestamp, len(seconds))
+	for i, s := range seconds {
+		res[i] = timestamppb.New(time.Unix(s, 0))
 	}
-	frames := map[string]*pb.Frame{}
+	return res
+}
 
-	t.Run("append the first frame", func(t *testing.T) {
-		appendMatchingFrames(frames, res)
+func seconds(ts []*timestamppb.Timestamp) []int64 {
+	res := make([]int64, len(ts))
+	for i := range ts {
+		res[i] = ts[i].AsTime().Unix()
+	}
+	return res
+}
 
-		assert.Equal(t, map[string]*pb.Frame{"temperature": res[0]}, frames)
-	})
-	t.Run("append the second frame", func(t *testing.T) {
-		appendMatchingFrames(frames, res)
-		exp := &pb.Frame{
-			Metric: "temperature",
-			Timestamps: []*timestamppb.Timestamp{
-				res[0].Timestamps[0], res[0].Timestamps[0],
-			},
-			Fields: []*pb.Field{res[0].Fields[0], res[0].Fields[0]},
+func mergePages(pages ...[]*pb.Frame) map[string]*pb.Frame {
+	merger := newFrameMerger()
+	for _, page := range pages {
+		merger.append(page)
+	}
+	res := map[string]*pb.Frame{}
+	for _, frame := range merger.result() {
+		res[frame.Metric] = frame
+	}
+	return res
+}
+
+func fieldByName(frame *pb.Frame, name string, labels ...*pb.Label) *pb.Field {
+	key := fieldKey(&pb.Field{Name: name, Labels: labels})
+	for _, fld := range frame.Fields {
+		if fieldKey(fld) == key {
+			return fld
 		}
-		assert.Equal(t, exp.Timestamps, frames["temperature"].Timestamps)
-		assert.Equal(t, exp.Fields[0].Values, frames["temperature"].Fields[0].Values)
+	}
+	return nil
+}
+
+// assertValues compares numeric values; NaN values are compared as missing values
+func assertValues(t *testing.T, exp []float64, actual []float64) {
+	t.Helper()
+	if !assert.Len(t, actual, len(exp)) {
+		return
+	}
+	for i := range exp {
+		if math.IsNaN(exp[i]) {
+			assert.True(t, math.IsNaN(actual[i]), "expected a missing value at index %d, got %v", i, actual[i])
+			continue
+		}
+		assert.Equal(t, exp[i], actual[i], "unexpected value at index %d", i)
+	}
+}
+
+func TestFrameMerger(t *testing.T) {
+	nan := math.NaN()
+
+	t.Run("the first page is returned as is", func(t *testing.T) {
+		res := mergePages([]*pb.Frame{
+			{
+				Metric:     "temperature",
+				Timestamps: timestamps(1, 2, 3),
+				Fields:     []*pb.Field{{Name: "value", Values: []float64{1, 2, 3}}},
+			},
+		})
+		assert.Equal(t, []int64{1, 2, 3}, seconds(res["temperature"].Timestamps))
+		assertValues(t, []float64{1, 2, 3}, res["temperature"].Fields[0].Values)
+	})
+
+	t.Run("the values of consecutive pages are appended", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1, 2), Fields: []*pb.Field{{Name: "value", Values: []float64{1, 2}}}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(3, 4), Fields: []*pb.Field{{Name: "value", Values: []float64{3, 4}}}}},
+		)
+		assert.Equal(t, []int64{1, 2, 3, 4}, seconds(res["temperature"].Timestamps))
+		assertValues(t, []float64{1, 2, 3, 4}, res["temperature"].Fields[0].Values)
+	})
+
+	t.Run("frames of different metrics are not merged", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1), Fields: []*pb.Field{{Name: "value", Values: []float64{1}}}}},
+			[]*pb.Frame{{Metric: "pressure", Timestamps: timestamps(2), Fields: []*pb.Field{{Name: "value", Values: []float64{2}}}}},
+		)
+		assert.Len(t, res, 2)
+		assert.Equal(t, []int64{1}, seconds(res["temperature"].Timestamps))
+		assert.Equal(t, []int64{2}, seconds(res["pressure"].Timestamps))
+	})
+
+	t.Run("a field which is missing in a page is padded", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1, 2), Fields: []*pb.Field{
+				{Name: "min", Values: []float64{1, 2}},
+				{Name: "max", Values: []float64{10, 20}},
+				{Name: "state", StringValues: []string{"on", "off"}},
+			}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(3), Fields: []*pb.Field{
+				{Name: "min", Values: []float64{3}},
+			}}},
+		)
+		frame := res["temperature"]
+		assertValues(t, []float64{1, 2, 3}, fieldByName(frame, "min").Values)
+		assertValues(t, []float64{10, 20, nan}, fieldByName(frame, "max").Values)
+		assert.Equal(t, []string{"on", "off", ""}, fieldByName(frame, "state").StringValues)
+	})
+
+	t.Run("the type of a field is decided by the first page which contains its values", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "machine", Timestamps: timestamps(1), Fields: []*pb.Field{
+				{Name: "state"},
+			}}},
+			[]*pb.Frame{{Metric: "machine", Timestamps: timestamps(2, 3), Fields: []*pb.Field{
+				{Name: "state", StringValues: []string{"on", "off"}},
+			}}},
+		)
+		state := fieldByName(res["machine"], "state")
+		assert.Equal(t, []string{"", "on", "off"}, state.StringValues)
+		assert.Empty(t, state.Values)
+	})
+
+	t.Run("a field which is introduced in a later page is padded", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1, 2), Fields: []*pb.Field{
+				{Name: "min", Values: []float64{1, 2}},
+			}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(3), Fields: []*pb.Field{
+				{Name: "min", Values: []float64{3}},
+				{Name: "max", Values: []float64{30}},
+			}}},
+		)
+		frame := res["temperature"]
+		assert.Len(t, frame.Fields, 2)
+		assertValues(t, []float64{1, 2, 3}, fieldByName(frame, "min").Values)
+		assertValues(t, []float64{nan, nan, 30}, fieldByName(frame, "max").Values)
+	})
+
+	t.Run("fields with the same name and different labels are kept apart", func(t *testing.T) {
+		zoneA := &pb.Label{Key: "zone", Value: "a"}
+		zoneB := &pb.Label{Key: "zone", Value: "b"}
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1), Fields: []*pb.Field{
+				{Name: "value", Labels: []*pb.Label{zoneA}, Values: []float64{1}},
+			}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(2), Fields: []*pb.Field{
+				{Name: "value", Labels: []*pb.Label{zoneB}, Values: []float64{2}},
+				{Name: "value", Labels: []*pb.Label{zoneA}, Values: []float64{3}},
+			}}},
+		)
+		frame := res["temperature"]
+		assert.Len(t, frame.Fields, 2)
+		assertValues(t, []float64{1, 3}, fieldByName(frame, "value", zoneA).Values)
+		assertValues(t, []float64{nan, 2}, fieldByName(frame, "value", zoneB).Values)
+	})
+
+	t.Run("overlapping timestamps are deduplicated", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1, 2, 3), Fields: []*pb.Field{{Name: "value", Values: []float64{1, 2, 3}}}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(3, 4), Fields: []*pb.Field{{Name: "value", Values: []float64{30, 4}}}}},
+		)
+		assert.Equal(t, []int64{1, 2, 3, 4}, seconds(res["temperature"].Timestamps))
+		assertValues(t, []float64{1, 2, 3, 4}, res["temperature"].Fields[0].Values)
+	})
+
+	t.Run("an overlapping timestamp fills a missing value", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1, 2), Fields: []*pb.Field{{Name: "min", Values: []float64{1, 2}}}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(2, 3), Fields: []*pb.Field{
+				{Name: "min", Values: []float64{2, 3}},
+				{Name: "max", Values: []float64{20, 30}},
+			}}},
+		)
+		frame := res["temperature"]
+		assertValues(t, []float64{1, 2, 3}, fieldByName(frame, "min").Values)
+		assertValues(t, []float64{nan, 20, 30}, fieldByName(frame, "max").Values)
+	})
+
+	t.Run("the values are sorted by time", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(4, 3), Fields: []*pb.Field{
+				{Name: "value", Values: []float64{4, 3}},
+				{Name: "state", StringValues: []string{"d", "c"}},
+			}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(2, 1), Fields: []*pb.Field{
+				{Name: "value", Values: []float64{2, 1}},
+				{Name: "state", StringValues: []string{"b", "a"}},
+			}}},
+		)
+		frame := res["temperature"]
+		assert.Equal(t, []int64{1, 2, 3, 4}, seconds(frame.Timestamps))
+		assertValues(t, []float64{1, 2, 3, 4}, fieldByName(frame, "value").Values)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, fieldByName(frame, "state").StringValues)
+	})
+
+	t.Run("notices of later pages are retained", func(t *testing.T) {
+		res := mergePages(
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(1), Fields: []*pb.Field{{Name: "value", Values: []float64{1}}}}},
+			[]*pb.Frame{{Metric: "temperature", Timestamps: timestamps(2), Fields: []*pb.Field{{Name: "value", Values: []float64{2}}},
+				Meta: &pb.FrameMeta{Notices: []*pb.FrameMeta_Notice{{Text: "sensor offline"}}}}},
+		)
+		assert.Len(t, res["temperature"].Meta.Notices, 1)
 	})
 }
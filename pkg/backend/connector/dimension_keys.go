@@ -5,34 +5,47 @@ import (
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/samber/lo"
 )
 
-func ListDimensionKeys(ctx context.Context, client client.BackendAPIClient, query models.GetDimensionKeysRequest) (*models.GetDimensionKeysResponse, error) {
+func listDimensionKeys(ctx context.Context, client client.BackendAPIClient, filter string, selected []models.Dimension) ([]*pb.ListDimensionKeysResponse_Result, error) {
 	resp, err := client.ListDimensionKeys(ctx, &pb.ListDimensionKeysRequest{
-		Filter: query.Filter,
-		SelectedDimensions: lo.Map(query.SelectedDimensions, func(dimension models.Dimension, _ int) *pb.Dimension {
-			return &pb.Dimension{
-				Key:   dimension.Key,
-				Value: dimension.Value,
-			}
-		}),
+		Filter:             filter,
+		SelectedDimensions: dimensionsToInput(selected),
 	})
-
 	if err != nil {
 		return nil, err
 	}
-	if resp == nil {
-		return nil, nil
+	return resp.GetResults(), nil
+}
+
+// ListDimensionKeys returns the dimension keys for the selected dimensions. If the backend does not support the
+// operators of the selected dimensions, it returns the keys for all dimension values which match the operators.
+func ListDimensionKeys(ctx context.Context, client client.BackendAPIClient, query models.GetDimensionKeysRequest) (*models.GetDimensionKeysResponse, error) {
+	results, err := listDimensionKeys(ctx, client, query.Filter, query.SelectedDimensions)
+	if operatorsNotSupported(err, query.SelectedDimensions) {
+		results = nil
+		err = forEachContext(ctx, client, query.SelectedDimensions, func(dimensions []models.Dimension) error {
+			res, err := listDimensionKeys(ctx, client, query.Filter, dimensions)
+			results = append(results, res...)
+			return err
+		})
+		results = lo.UniqBy(results, func(r *pb.ListDimensionKeysResponse_Result) string { return r.Key })
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &models.GetDimensionKeysResponse{
-		Keys: lo.Map(resp.GetResults(), func(dimension *pb.ListDimensionKeysResponse_Result, _ int) models.DimensionKeyDefinition {
+		Keys: lo.Map(results, func(dimension *pb.ListDimensionKeysResponse_Result, _ int) models.DimensionKeyDefinition {
 			return models.DimensionKeyDefinition{
 				Value:       dimension.Key,
-				Label:       dimension.Key,
+				Label:       labelOrID(dimension.Label, dimension.Key),
 				Description: dimension.Description,
+				Group:       dimension.Group,
+				Icon:        dimension.Icon,
+				Color:       dimension.Color,
 			}
 		}),
 	}, nil
@@ -10,7 +10,7 @@ import (
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 )
 
 type clientMock struct {
@@ -19,9 +19,9 @@ type clientMock struct {
 }
 
 // Returns a list of all available dimensions
-func (clientmock *clientMock) ListDimensionKeys(ctx context.Context, in *v3.ListDimensionKeysRequest, opts ...grpc.CallOption) (*v3.ListDimensionKeysResponse, error) {
+func (clientmock *clientMock) ListDimensionKeys(ctx context.Context, in *v5.ListDimensionKeysRequest, opts ...grpc.CallOption) (*v5.ListDimensionKeysResponse, error) {
 	args := clientmock.Called(ctx, in, opts)
-	if v, ok := args.Get(0).(*v3.ListDimensionKeysResponse); ok {
+	if v, ok := args.Get(0).(*v5.ListDimensionKeysResponse); ok {
 		return v, args.Error(1)
 	}
 	return nil, args.Error(1)
@@ -35,17 +35,23 @@ func TestListDimensionKeys(t *testing.T) {
 			{Key: "foo", Value: "bar"},
 		},
 	}
-	m.On("ListDimensionKeys", mock.Anything, &v3.ListDimensionKeysRequest{
+	m.On("ListDimensionKeys", mock.Anything, &v5.ListDimensionKeysRequest{
 		Filter: req.Filter,
-		SelectedDimensions: []*v3.Dimension{
+		SelectedDimensions: []*v5.Dimension{
 			{Key: "foo", Value: "bar"},
 		},
-	}, mock.Anything).Return(&v3.ListDimensionKeysResponse{
-		Results: []*v3.ListDimensionKeysResponse_Result{
+	}, mock.Anything).Return(&v5.ListDimensionKeysResponse{
+		Results: []*v5.ListDimensionKeysResponse_Result{
 			{
 				Key:         "foo",
 				Description: "bar",
 			},
+			{
+				Key:   "a81bc81b",
+				Label: "Machine",
+				Group: "Asset",
+				Icon:  "cog",
+			},
 		},
 	}, nil)
 	res, err := ListDimensionKeys(context.TODO(), m, req)
@@ -54,6 +60,7 @@ func TestListDimensionKeys(t *testing.T) {
 	exp := &models.GetDimensionKeysResponse{
 		Keys: []models.DimensionKeyDefinition{
 			{Value: "foo", Label: "foo", Description: "bar"},
+			{Value: "a81bc81b", Label: "Machine", Group: "Asset", Icon: "cog"},
 		},
 	}
 	assert.EqualValues(t, exp, res)
@@ -5,35 +5,48 @@ import (
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/samber/lo"
 )
 
-func ListDimensionValues(ctx context.Context, client client.BackendAPIClient, query models.GetDimensionValuesRequest) (*models.GetDimensionValueResponse, error) {
+func listDimensionValues(ctx context.Context, client client.BackendAPIClient, query models.GetDimensionValuesRequest, selected []models.Dimension) ([]*pb.ListDimensionValuesResponse_Result, error) {
 	resp, err := client.ListDimensionValues(ctx, &pb.ListDimensionValuesRequest{
-		DimensionKey: query.DimensionKey,
-		Filter:       query.Filter,
-		SelectedDimensions: lo.Map(query.SelectedDimensions, func(dimension models.Dimension, _ int) *pb.Dimension {
-			return &pb.Dimension{
-				Key:   dimension.Key,
-				Value: dimension.Value,
-			}
-		}),
+		DimensionKey:       query.DimensionKey,
+		Filter:             query.Filter,
+		SelectedDimensions: dimensionsToInput(selected),
 	})
-
 	if err != nil {
 		return nil, err
 	}
-	if resp == nil {
-		return nil, nil
+	return resp.GetResults(), nil
+}
+
+// ListDimensionValues returns the values of a dimension for the selected dimensions. If the backend does not support
+// the operators of the selected dimensions, it returns the values for all dimension values which match the operators.
+func ListDimensionValues(ctx context.Context, client client.BackendAPIClient, query models.GetDimensionValuesRequest) (*models.GetDimensionValueResponse, error) {
+	results, err := listDimensionValues(ctx, client, query, query.SelectedDimensions)
+	if operatorsNotSupported(err, query.SelectedDimensions) {
+		results = nil
+		err = forEachContext(ctx, client, query.SelectedDimensions, func(dimensions []models.Dimension) error {
+			res, err := listDimensionValues(ctx, client, query, dimensions)
+			results = append(results, res...)
+			return err
+		})
+		results = lo.UniqBy(results, func(r *pb.ListDimensionValuesResponse_Result) string { return r.Value })
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	return &models.GetDimensionValueResponse{
-		Values: lo.Map(resp.GetResults(), func(dimension *pb.ListDimensionValuesResponse_Result, _ int) models.DimensionValueDefinition {
+		Values: lo.Map(results, func(dimension *pb.ListDimensionValuesResponse_Result, _ int) models.DimensionValueDefinition {
 			return models.DimensionValueDefinition{
 				Value:       dimension.Value,
-				Label:       dimension.Value,
+				Label:       labelOrID(dimension.Label, dimension.Value),
 				Description: dimension.Description,
+				Group:       dimension.Group,
+				Icon:        dimension.Icon,
+				Color:       dimension.Color,
 			}
 		}),
 	}, nil
@@ -5,17 +5,18 @@ import (
 	"testing"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
 )
 
 // Returns a list of all dimension values for a certain dimension
-func (clientmock *clientMock) ListDimensionValues(ctx context.Context, in *v3.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v3.ListDimensionValuesResponse, error) {
-	return &v3.ListDimensionValuesResponse{
-		Results: []*v3.ListDimensionValuesResponse_Result{
+func (clientmock *clientMock) ListDimensionValues(ctx context.Context, in *v5.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v5.ListDimensionValuesResponse, error) {
+	return &v5.ListDimensionValuesResponse{
+		Results: []*v5.ListDimensionValuesResponse_Result{
 			{Value: "foo", Description: "bar"},
+			{Value: "5d41402a", Label: "Press 1", Group: "Hall A", Color: "#73bf69"},
 		},
 	}, nil
 }
@@ -29,14 +30,14 @@ func TestListDimensionValues(t *testing.T) {
 			{Key: "foo", Value: "bar"},
 		},
 	}
-	m.On("ListDimensionValues", mock.Anything, &v3.ListDimensionValuesRequest{
+	m.On("ListDimensionValues", mock.Anything, &v5.ListDimensionValuesRequest{
 		Filter:       req.Filter,
 		DimensionKey: "foo",
-		SelectedDimensions: []*v3.Dimension{
+		SelectedDimensions: []*v5.Dimension{
 			{Key: "foo", Value: "bar"},
 		},
-	}, mock.Anything).Return(&v3.ListDimensionValuesResponse{
-		Results: []*v3.ListDimensionValuesResponse_Result{
+	}, mock.Anything).Return(&v5.ListDimensionValuesResponse{
+		Results: []*v5.ListDimensionValuesResponse_Result{
 			{
 				Value:       "foo",
 				Description: "bar",
@@ -49,6 +50,7 @@ func TestListDimensionValues(t *testing.T) {
 	exp := &models.GetDimensionValueResponse{
 		Values: []models.DimensionValueDefinition{
 			{Value: "foo", Label: "foo", Description: "bar"},
+			{Value: "5d41402a", Label: "Press 1", Group: "Hall A", Color: "#73bf69"},
 		},
 	}
 	assert.EqualValues(t, exp, res)
@@ -0,0 +1,77 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+)
+
+// maxContextCombinations is the max. number of requests which are sent for the context filter of a metadata request
+// if the backend does not support dimension operators
+const maxContextCombinations = 100
+
+// labelOrID returns the label of a listed dimension key, dimension value or metric; servers which do not return a label
+// (e.g. a server with an older protocol version) show the id instead
+func labelOrID(label, id string) string {
+	if label == "" {
+		return id
+	}
+	return label
+}
+
+func operatorToInput(operator models.DimensionOperator) pb.DimensionOperator {
+	switch operator {
+	case models.DimensionOperatorNotEqual:
+		return pb.DimensionOperator_DIMENSION_OPERATOR_NOT_EQUAL
+	case models.DimensionOperatorRegex:
+		return pb.DimensionOperator_DIMENSION_OPERATOR_REGEX
+	case models.DimensionOperatorIn:
+		return pb.DimensionOperator_DIMENSION_OPERATOR_IN
+	default:
+		return pb.DimensionOperator_DIMENSION_OPERATOR_EQUAL
+	}
+}
+
+func dimensionsToInput(dimensions []models.Dimension) []*pb.Dimension {
+	if len(dimensions) == 0 {
+		return nil
+	}
+	return lo.Map(dimensions, func(d models.Dimension, _ int) *pb.Dimension {
+		dimension := &pb.Dimension{
+			Key:      d.Key,
+			Value:    d.Value,
+			Operator: operatorToInput(d.Operator),
+		}
+		if d.Operator == models.DimensionOperatorIn {
+			dimension.Values = d.Values
+		}
+		return dimension
+	})
+}
+
+// operatorsNotSupported returns whether a request with dimension operators failed because the backend only supports
+// equality of dimensions
+func operatorsNotSupported(err error, dimensions []models.Dimension) bool {
+	return status.Code(err) == codes.Unimplemented && lo.SomeBy(dimensions, models.Dimension.HasOperator)
+}
+
+// forEachContext calls fn for each combination of the dimension values which match the operators of a context filter.
+// It is used for a backend which only supports equality of dimensions.
+func forEachContext(ctx context.Context, client client.BackendAPIClient, dimensions []models.Dimension, fn func(dimensions []models.Dimension) error) error {
+	queries, err := ExpandOperators(ctx, client, models.MetricBaseQuery{Dimensions: dimensions}, maxContextCombinations)
+	if err != nil {
+		return err
+	}
+	for _, q := range queries {
+		if err := fn(q.Query.Dimensions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package connector
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// downsampleFrames reduces the number of values of each frame to approximately maxDataPoints.
+// Frames with fewer values are returned as is.
+func downsampleFrames(frames []*pb.Frame, algorithm models.DownsamplingAlgorithm, maxDataPoints int64) []*pb.Frame {
+	if algorithm == models.DownsamplingNone || maxDataPoints <= 0 {
+		return frames
+	}
+	res := make([]*pb.Frame, len(frames))
+	for i, frame := range frames {
+		res[i] = downsampleFrame(frame, algorithm, int(maxDataPoints))
+	}
+	return res
+}
+
+func downsampleFrame(frame *pb.Frame, algorithm models.DownsamplingAlgorithm, threshold int) *pb.Frame {
+	n := len(frame.Timestamps)
+	if n <= threshold || threshold < 3 {
+		return frame
+	}
+	times := make([]float64, n)
+	for i, ts := range frame.Timestamps {
+		times[i] = float64(ts.AsTime().UnixNano())
+	}
+
+	var res *pb.Frame
+	switch algorithm {
+	case models.DownsamplingLTTB:
+		rows := unionOfFields(frame, threshold, func(values []float64, budget int) []int {
+			return lttb(times, values, budget)
+		})
+		if len(rows) == 0 {
+			return frame
+		}
+		res = selectRows(frame, rows)
+	case models.DownsamplingMinMax:
+		rows := unionOfFields(frame, threshold, func(values []float64, budget int) []int {
+			return minMaxPerBucket(values, timeBuckets(times, (budget-2)/2))
+		})
+		if len(rows) == 0 {
+			return frame
+		}
+		res = selectRows(frame, rows)
+	case models.DownsamplingAverage:
+		res = averagePerBucket(frame, timeBuckets(times, threshold))
+	default:
+		return frame
+	}
+
+	meta := &pb.FrameMeta{}
+	if frame.Meta != nil {
+		meta = cloneMeta(frame.Meta)
+	}
+	meta.Notices = append(meta.Notices, &pb.FrameMeta_Notice{
+		Severity: pb.FrameMeta_Notice_NoticeSeverityInfo,
+		Text:     fmt.Sprintf("The data is reduced from %d to %d points using %s downsampling", n, len(res.Timestamps), algorithm),
+	})
+	res.Meta = meta
+	return res
+}
+
+// cloneMeta returns a shallow copy of the frame meta, so the notices of the original frame are not modified
+func cloneMeta(meta *pb.FrameMeta) *pb.FrameMeta {
+	return &pb.FrameMeta{
+		Type:                   meta.Type,
+		Notices:                append([]*pb.FrameMeta_Notice{}, meta.Notices...),
+		PreferredVisualization: meta.PreferredVisualization,
+		ExecutedQueryString:    meta.ExecutedQueryString,
+	}
+}
+
+// unionOfFields returns the (sorted) union of the rows which are selected for the numeric fields of a frame. The
+// fields share the threshold, so the union never exceeds it; if the share of a field is too small to select its
+// first, last and at least one intermediate row, the rows are selected by the first numeric field only.
+func unionOfFields(frame *pb.Frame, threshold int, selectFn func(values []float64, budget int) []int) []int {
+	var fields []*pb.Field
+	for _, fld := range frame.Fields {
+		if len(fld.StringValues) == 0 && len(fld.Values) == len(frame.Timestamps) {
+			fields = append(fields, fld)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	budget := threshold / len(fields)
+	if budget < 3 {
+		fields, budget = fields[:1], threshold
+	}
+	selected := map[int]bool{}
+	for _, fld := range fields {
+		for _, idx := range selectFn(fld.Values, budget) {
+			selected[idx] = true
+		}
+	}
+	rows := make([]int, 0, len(selected))
+	for idx := range selected {
+		rows = append(rows, idx)
+	}
+	sort.Ints(rows)
+	return rows
+}
+
+// selectRows returns a frame which only contains the specified rows
+func selectRows(frame *pb.Frame, rows []int) *pb.Frame {
+	res := &pb.Frame{
+		Metric:     frame.Metric,
+		Meta:       frame.Meta,
+		Timestamps: make([]*timestamppb.Timestamp, len(rows)),
+	}
+	for i, row := range rows {
+		res.Timestamps[i] = frame.Timestamps[row]
+	}
+	for _, fld := range frame.Fields {
+		newField := &pb.Field{Name: fld.Name, Labels: fld.Labels, Config: fld.Config}
+		for _, row := range rows {
+			if row < len(fld.StringValues) {
+				newField.StringValues = append(newField.StringValues, fld.StringValues[row])
+			}
+			if row < len(fld.Values) {
+				newField.Values = append(newField.Values, fld.Values[row])
+			}
+		}
+		res.Fields = append(res.Fields, newField)
+	}
+	return res
+}
+
+// sliceRows returns a frame which only contains the rows in the range [start, end)
+func sliceRows(frame *pb.Frame, start, end int) *pb.Frame {
+	rows := make([]int, 0, end-start)
+	for row := start; row < end; row++ {
+		rows = append(rows, row)
+	}
+	return selectRows(frame, rows)
+}
+
+// validPoints returns the indices of all values which are not missing
+func validPoints(values []float64) []int {
+	res := make([]int, 0, len(values))
+	for i, v := range values {
+		if !math.IsNaN(v) {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// lttb returns the indices of the points which are selected by the Largest-Triangle-Three-Buckets algorithm
+func lttb(times []float64, values []float64, threshold int) []int {
+	points := validPoints(values)
+	n := len(points)
+	if n <= threshold {
+		return points
+	}
+
+	res := make([]int, 0, threshold)
+	res = append(res, points[0])
+
+	bucketSize := float64(n-2) / float64(threshold-2)
+	a := 0
+	for i := 0; i < threshold-2; i++ {
+		// the average point of the next bucket
+		nextStart := int(math.Floor(float64(i+1)*bucketSize)) + 1
+		nextEnd := int(math.Floor(float64(i+2)*bucketSize)) + 1
+		if nextEnd > n {
+			nextEnd = n
+		}
+		var avgX, avgY float64
+		for j := nextStart; j < nextEnd; j++ {
+			avgX += times[points[j]]
+			avgY += values[points[j]]
+		}
+		count := float64(nextEnd - nextStart)
+		avgX /= count
+		avgY /= count
+
+		// the point of the current bucket which forms the largest triangle with the previous selected point and the average point
+		start := int(math.Floor(float64(i)*bucketSize)) + 1
+		end := int(math.Floor(float64(i+1)*bucketSize)) + 1
+		ax, ay := times[points[a]], values[points[a]]
+		maxArea, selected := -1.0, start
+		for j := start; j < end; j++ {
+			area := math.Abs((ax-avgX)*(values[points[j]]-ay) - (ax-times[points[j]])*(avgY-ay))
+			if area > maxArea {
+				maxArea, selected = area, j
+			}
+		}
+		res = append(res, points[selected])
+		a = selected
+	}
+	return append(res, points[n-1])
+}
+
+// timeBuckets divides the rows into buckets of equal duration; it returns the first row of each bucket.
+func timeBuckets(times []float64, count int) []int {
+	if count < 1 {
+		count = 1
+	}
+	start, end := times[0], times[len(times)-1]
+	width := (end - start) / float64(count)
+	res := []int{0}
+	if width <= 0 {
+		return res
+	}
+	for i := range times {
+		bucket := int((times[i] - start) / width)
+		if bucket >= count {
+			bucket = count - 1
+		}
+		for len(res) <= bucket {
+			res = append(res, i)
+		}
+	}
+	return res
+}
+
+// bucketRange returns the range of rows of a bucket
+func bucketRange(buckets []int, i int, n int) (int, int) {
+	if i == len(buckets)-1 {
+		return buckets[i], n
+	}
+	return buckets[i], buckets[i+1]
+}
+
+// minMaxPerBucket returns the indices of the min and max value of each bucket; the first and last value are always retained
+func minMaxPerBucket(values []float64, buckets []int) []int {
+	points := validPoints(values)
+	if len(points) == 0 {
+		return nil
+	}
+	res := []int{points[0], points[len(points)-1]}
+	for i := range buckets {
+		start, end := bucketRange(buckets, i, len(values))
+		minIdx, maxIdx := -1, -1
+		for j := start; j < end; j++ {
+			if math.IsNaN(values[j]) {
+				continue
+			}
+			if minIdx < 0 || values[j] < values[minIdx] {
+				minIdx = j
+			}
+			if maxIdx < 0 || values[j] > values[maxIdx] {
+				maxIdx = j
+			}
+		}
+		if minIdx >= 0 {
+			res = append(res, minIdx, maxIdx)
+		}
+	}
+	return res
+}
+
+// averagePerBucket returns a frame with the average value of each bucket; string fields get the last value of the bucket.
+// Missing values (NaN or an empty string) are skipped; a bucket without values is missing.
+func averagePerBucket(frame *pb.Frame, buckets []int) *pb.Frame {
+	n := len(frame.Timestamps)
+	res := &pb.Frame{
+		Metric: frame.Metric,
+		Meta:   frame.Meta,
+	}
+	for i := range buckets {
+		start, end := bucketRange(buckets, i, n)
+		if start < end {
+			res.Timestamps = append(res.Timestamps, frame.Timestamps[start])
+		}
+	}
+	for _, fld := range frame.Fields {
+		newField := &pb.Field{Name: fld.Name, Labels: fld.Labels, Config: fld.Config}
+		for i := range buckets {
+			start, end := bucketRange(buckets, i, n)
+			if start >= end {
+				continue
+			}
+			if len(fld.StringValues) > 0 {
+				newField.StringValues = append(newField.StringValues, lastString(fld.StringValues, start, end))
+				continue
+			}
+			sum, count := 0.0, 0
+			for j := start; j < end && j < len(fld.Values); j++ {
+				if !math.IsNaN(fld.Values[j]) {
+					sum += fld.Values[j]
+					count++
+				}
+			}
+			if count == 0 {
+				newField.Values = append(newField.Values, math.NaN())
+			} else {
+				newField.Values = append(newField.Values, sum/float64(count))
+			}
+		}
+		res.Fields = append(res.Fields, newField)
+	}
+	return res
+}
+
+// lastString returns the last string in the range [start, end) which is not missing (empty)
+func lastString(values []string, start, end int) string {
+	for j := min(end, len(values)) - 1; j >= start; j-- {
+		if values[j] != "" {
+			return values[j]
+		}
+	}
+	return ""
+}
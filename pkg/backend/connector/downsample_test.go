@@ -0,0 +1,130 @@
+package connector
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// sineFrame returns a frame with n values of a sine wave with a single spike
+func sineFrame(n int) *pb.Frame {
+	frame := &pb.Frame{
+		Metric: "temperature",
+		Fields: []*pb.Field{{Name: "value"}, {Name: "state"}},
+	}
+	for i := 0; i < n; i++ {
+		frame.Timestamps = append(frame.Timestamps, timestamppb.New(time.Unix(int64(i), 0)))
+		v := math.Sin(float64(i) / 10)
+		if i == n/3 {
+			v = 100
+		}
+		frame.Fields[0].Values = append(frame.Fields[0].Values, v)
+		frame.Fields[1].StringValues = append(frame.Fields[1].StringValues, "on")
+	}
+	return frame
+}
+
+func TestDownsampleFrames(t *testing.T) {
+	for _, algorithm := range []models.DownsamplingAlgorithm{models.DownsamplingLTTB, models.DownsamplingMinMax, models.DownsamplingAverage} {
+		t.Run(string(algorithm), func(t *testing.T) {
+			frame := sineFrame(1000)
+			res := downsampleFrames([]*pb.Frame{frame}, algorithm, 100)[0]
+
+			t.Run("the number of values is reduced", func(t *testing.T) {
+				assert.LessOrEqual(t, len(res.Timestamps), 100)
+				assert.Greater(t, len(res.Timestamps), 50)
+			})
+			t.Run("all fields have the same length", func(t *testing.T) {
+				assert.Len(t, res.Fields[0].Values, len(res.Timestamps))
+				assert.Len(t, res.Fields[1].StringValues, len(res.Timestamps))
+			})
+			t.Run("the values are sorted by time", func(t *testing.T) {
+				assert.True(t, lo.IsSortedByKey(res.Timestamps, func(ts *timestamppb.Timestamp) int64 { return ts.AsTime().UnixNano() }))
+			})
+			t.Run("the reduction is noted in the frame meta", func(t *testing.T) {
+				if assert.Len(t, res.Meta.Notices, 1) {
+					assert.Contains(t, res.Meta.Notices[0].Text, "from 1000 to")
+				}
+			})
+			t.Run("the original frame is not modified", func(t *testing.T) {
+				assert.Len(t, frame.Timestamps, 1000)
+				assert.Nil(t, frame.Meta)
+			})
+		})
+	}
+
+	t.Run("lttb and minmax retain the first, last and extreme values", func(t *testing.T) {
+		for _, algorithm := range []models.DownsamplingAlgorithm{models.DownsamplingLTTB, models.DownsamplingMinMax} {
+			res := downsampleFrames([]*pb.Frame{sineFrame(1000)}, algorithm, 100)[0]
+			assert.Contains(t, res.Fields[0].Values, float64(100), algorithm)
+			assert.Equal(t, int64(0), res.Timestamps[0].AsTime().Unix(), algorithm)
+			assert.Equal(t, int64(999), res.Timestamps[len(res.Timestamps)-1].AsTime().Unix(), algorithm)
+		}
+	})
+
+	t.Run("the fields of a frame share the max. number of values", func(t *testing.T) {
+		multiField := func(fields int) *pb.Frame {
+			frame := &pb.Frame{Metric: "temperature"}
+			for f := 0; f < fields; f++ {
+				frame.Fields = append(frame.Fields, &pb.Field{Name: fmt.Sprintf("zone%d", f)})
+			}
+			for i := 0; i < 1000; i++ {
+				frame.Timestamps = append(frame.Timestamps, timestamppb.New(time.Unix(int64(i), 0)))
+				for f, fld := range frame.Fields {
+					fld.Values = append(fld.Values, math.Sin(float64(i+f*7)/float64(3+f)))
+				}
+			}
+			return frame
+		}
+		for _, algorithm := range []models.DownsamplingAlgorithm{models.DownsamplingLTTB, models.DownsamplingMinMax} {
+			for _, fields := range []int{3, 60} {
+				res := downsampleFrames([]*pb.Frame{multiField(fields)}, algorithm, 100)[0]
+				assert.LessOrEqual(t, len(res.Timestamps), 100, "%s with %d fields", algorithm, fields)
+				assert.Greater(t, len(res.Timestamps), 30, "%s with %d fields", algorithm, fields)
+			}
+		}
+	})
+
+	t.Run("avg returns the average value of each bucket", func(t *testing.T) {
+		frame := &pb.Frame{Metric: "temperature", Fields: []*pb.Field{{Name: "value"}}}
+		for i := 0; i < 10; i++ {
+			frame.Timestamps = append(frame.Timestamps, timestamppb.New(time.Unix(int64(i), 0)))
+			frame.Fields[0].Values = append(frame.Fields[0].Values, float64(i))
+		}
+		frame.Fields[0].Values[1] = math.NaN()
+
+		res := downsampleFrames([]*pb.Frame{frame}, models.DownsamplingAverage, 3)[0]
+		assert.Equal(t, []int64{0, 3, 6}, seconds(res.Timestamps))
+		assert.Equal(t, []float64{1, 4, 7.5}, res.Fields[0].Values)
+	})
+
+	t.Run("avg skips missing values", func(t *testing.T) {
+		nan := math.NaN()
+		frame := &pb.Frame{Metric: "machine", Timestamps: timestamps(0, 1, 2, 3, 4, 5, 6, 7, 8), Fields: []*pb.Field{
+			{Name: "value", Values: []float64{1, nan, 3, nan, nan, nan, 7, 8, 9}},
+			{Name: "state", StringValues: []string{"on", "off", "", "", "", "", "on", "", ""}},
+		}}
+		res := downsampleFrames([]*pb.Frame{frame}, models.DownsamplingAverage, 3)[0]
+		assertValues(t, []float64{2, nan, 8}, res.Fields[0].Values)
+		assert.Equal(t, []string{"off", "", "on"}, res.Fields[1].StringValues, "a bucket without strings is missing")
+	})
+
+	t.Run("frames with fewer values are not modified", func(t *testing.T) {
+		frame := sineFrame(50)
+		res := downsampleFrames([]*pb.Frame{frame}, models.DownsamplingLTTB, 100)
+		assert.Same(t, frame, res[0])
+	})
+
+	t.Run("frames are not modified if downsampling is not enabled", func(t *testing.T) {
+		frame := sineFrame(1000)
+		res := downsampleFrames([]*pb.Frame{frame}, models.DownsamplingNone, 100)
+		assert.Same(t, frame, res[0])
+	})
+}
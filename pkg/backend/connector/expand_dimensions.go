@@ -0,0 +1,121 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+)
+
+// ExpandedQuery is a query for a single combination of the values of the multi-valued dimensions of a query
+type ExpandedQuery struct {
+	Query models.MetricBaseQuery
+	// Labels are the keys and values of the expanded dimensions; they distinguish the series of the expanded queries
+	Labels map[string]string
+}
+
+// ExpandDimensions returns a query for each combination of the values of the multi-valued dimensions of a query.
+// The values of a wildcard dimension are retrieved with ListDimensionValues, using the single-valued dimensions of
+// the query as context. It returns an error if the query expands to more than maxQueries queries.
+func ExpandDimensions(ctx context.Context, client client.BackendAPIClient, query models.MetricBaseQuery, maxQueries int) ([]ExpandedQuery, error) {
+	return expandDimensions(ctx, client, query, maxQueries, models.Dimension.IsMultiValued)
+}
+
+// IsExpandedByOperators returns whether a dimension is expanded by ExpandOperators
+func IsExpandedByOperators(d models.Dimension) bool {
+	return d.IsMultiValued() || d.HasOperator()
+}
+
+// ExpandOperators is like ExpandDimensions, but it also expands the dimensions with an operator to the dimension
+// values which match the operator. It is used for a backend which only supports equality of dimensions.
+func ExpandOperators(ctx context.Context, client client.BackendAPIClient, query models.MetricBaseQuery, maxQueries int) ([]ExpandedQuery, error) {
+	return expandDimensions(ctx, client, query, maxQueries, IsExpandedByOperators)
+}
+
+func expandDimensions(ctx context.Context, client client.BackendAPIClient, query models.MetricBaseQuery, maxQueries int, expand func(models.Dimension) bool) ([]ExpandedQuery, error) {
+	selected := lo.Reject(query.Dimensions, func(d models.Dimension, _ int) bool { return expand(d) })
+
+	res := []ExpandedQuery{{Query: query, Labels: map[string]string{}}}
+	res[0].Query.Dimensions = nil
+	for _, dimension := range query.Dimensions {
+		values := []string{dimension.Value}
+		if expand(dimension) {
+			var err error
+			if values, err = dimensionValues(ctx, client, dimension, selected); err != nil {
+				return nil, err
+			}
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		if n := len(res) * len(values); n > maxQueries {
+			return nil, status.Errorf(codes.InvalidArgument, "the query expands to at least %d series, which exceeds the max. of %d; please select fewer dimension values", n, maxQueries)
+		}
+
+		expanded := make([]ExpandedQuery, 0, len(res)*len(values))
+		for _, q := range res {
+			for _, value := range values {
+				e := ExpandedQuery{
+					Query:  q.Query,
+					Labels: lo.Assign(q.Labels),
+				}
+				d := dimension
+				if expand(dimension) {
+					d = models.Dimension{Key: dimension.Key, Value: value}
+					e.Labels[dimension.Key] = value
+				}
+				e.Query.Dimensions = append(append([]models.Dimension{}, q.Query.Dimensions...), d)
+				expanded = append(expanded, e)
+			}
+		}
+		res = expanded
+	}
+	return res, nil
+}
+
+// dimensionValues returns the values of a multi-valued dimension; a wildcard is replaced by all values of the dimension.
+// For a dimension with an operator it returns the values of the dimension which match the operator.
+func dimensionValues(ctx context.Context, client client.BackendAPIClient, dimension models.Dimension, selected []models.Dimension) ([]string, error) {
+	listValues := func() ([]string, error) {
+		resp, err := client.ListDimensionValues(ctx, &pb.ListDimensionValuesRequest{
+			DimensionKey:       dimension.Key,
+			SelectedDimensions: dimensionsToInput(selected),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return lo.Map(resp.GetResults(), func(r *pb.ListDimensionValuesResponse_Result, _ int) string { return r.Value }), nil
+	}
+
+	switch {
+	case dimension.Operator == models.DimensionOperatorIn:
+		return lo.Uniq(dimension.Values), nil
+	case dimension.HasOperator():
+		matches, err := dimension.Matcher()
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		values, err := listValues()
+		if err != nil {
+			return nil, err
+		}
+		return lo.Uniq(lo.Filter(values, func(v string, _ int) bool { return matches(v) })), nil
+	}
+
+	values := dimension.Values
+	if dimension.Value != "" && dimension.Value != models.DimensionWildcard {
+		values = append([]string{dimension.Value}, values...)
+	}
+	if dimension.Value == models.DimensionWildcard || lo.Contains(values, models.DimensionWildcard) {
+		var err error
+		if values, err = listValues(); err != nil {
+			return nil, err
+		}
+	}
+	return lo.Uniq(values), nil
+}
@@ -0,0 +1,119 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// dimensionValuesMock returns the values of a dimension by key
+type dimensionValuesMock struct {
+	client.BackendAPIClient
+	values   map[string][]string
+	requests []*v5.ListDimensionValuesRequest
+}
+
+func (m *dimensionValuesMock) ListDimensionValues(ctx context.Context, in *v5.ListDimensionValuesRequest, opts ...grpc.CallOption) (*v5.ListDimensionValuesResponse, error) {
+	m.requests = append(m.requests, in)
+	return &v5.ListDimensionValuesResponse{
+		Results: lo.Map(m.values[in.DimensionKey], func(v string, _ int) *v5.ListDimensionValuesResponse_Result {
+			return &v5.ListDimensionValuesResponse_Result{Value: v}
+		}),
+	}, nil
+}
+
+func TestExpandDimensions(t *testing.T) {
+	m := &dimensionValuesMock{values: map[string][]string{"machine": {"m1", "m2", "m3"}}}
+
+	t.Run("a wildcard is expanded with the other dimensions as context", func(t *testing.T) {
+		query := models.MetricBaseQuery{
+			Dimensions: []models.Dimension{{Key: "line", Value: "3"}, {Key: "machine", Value: "*"}},
+			Metrics:    []models.Metric{{MetricId: "temperature"}},
+		}
+		res, err := ExpandDimensions(context.TODO(), m, query, 10)
+		assert.NoError(t, err)
+
+		if assert.Len(t, res, 3) {
+			assert.Equal(t, []models.Dimension{{Key: "line", Value: "3"}, {Key: "machine", Value: "m2"}}, res[1].Query.Dimensions)
+			assert.Equal(t, map[string]string{"machine": "m2"}, res[1].Labels)
+			assert.Equal(t, query.Metrics, res[1].Query.Metrics)
+		}
+		if assert.Len(t, m.requests, 1) {
+			assert.Equal(t, "machine", m.requests[0].DimensionKey)
+			assert.Equal(t, []*v5.Dimension{{Key: "line", Value: "3"}}, m.requests[0].SelectedDimensions)
+		}
+	})
+
+	t.Run("multiple values are combined", func(t *testing.T) {
+		query := models.MetricBaseQuery{
+			Dimensions: []models.Dimension{{Key: "line", Values: []string{"1", "2"}}, {Key: "shift", Value: "a", Values: []string{"b"}}},
+		}
+		res, err := ExpandDimensions(context.TODO(), m, query, 10)
+		assert.NoError(t, err)
+		labels := lo.Map(res, func(q ExpandedQuery, _ int) map[string]string { return q.Labels })
+		assert.Equal(t, []map[string]string{
+			{"line": "1", "shift": "a"},
+			{"line": "1", "shift": "b"},
+			{"line": "2", "shift": "a"},
+			{"line": "2", "shift": "b"},
+		}, labels)
+	})
+
+	t.Run("the fan-out is limited", func(t *testing.T) {
+		query := models.MetricBaseQuery{
+			Dimensions: []models.Dimension{{Key: "line", Values: []string{"1", "2"}}, {Key: "machine", Value: "*"}},
+		}
+		_, err := ExpandDimensions(context.TODO(), m, query, 5)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestExpandOperators(t *testing.T) {
+	m := &dimensionValuesMock{values: map[string][]string{"machine": {"press-1", "press-2", "lathe-1"}}}
+
+	tests := []struct {
+		name      string
+		dimension models.Dimension
+		want      []string
+	}{
+		{name: "not equal", dimension: models.Dimension{Key: "machine", Value: "press-1", Operator: models.DimensionOperatorNotEqual}, want: []string{"press-2", "lathe-1"}},
+		{name: "regex", dimension: models.Dimension{Key: "machine", Value: "press-.*", Operator: models.DimensionOperatorRegex}, want: []string{"press-1", "press-2"}},
+		{name: "in", dimension: models.Dimension{Key: "machine", Values: []string{"lathe-1", "drill-1"}, Operator: models.DimensionOperatorIn}, want: []string{"lathe-1", "drill-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query := models.MetricBaseQuery{Dimensions: []models.Dimension{{Key: "line", Value: "3"}, tt.dimension}}
+			res, err := ExpandOperators(context.TODO(), m, query, 10)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, lo.Map(res, func(q ExpandedQuery, _ int) string { return q.Labels["machine"] }))
+			for _, q := range res {
+				assert.Equal(t, []models.Dimension{{Key: "line", Value: "3"}, {Key: "machine", Value: q.Labels["machine"]}}, q.Query.Dimensions)
+			}
+		})
+	}
+
+	t.Run("operators are not expanded by ExpandDimensions", func(t *testing.T) {
+		dimension := models.Dimension{Key: "machine", Value: "press-1", Operator: models.DimensionOperatorNotEqual}
+		query := models.MetricBaseQuery{Dimensions: []models.Dimension{{Key: "line", Values: []string{"1", "2"}}, dimension}}
+		res, err := ExpandDimensions(context.TODO(), m, query, 10)
+		assert.NoError(t, err)
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, []models.Dimension{{Key: "line", Value: "1"}, dimension}, res[0].Query.Dimensions)
+			assert.Equal(t, map[string]string{"line": "1"}, res[0].Labels)
+		}
+	})
+
+	t.Run("an invalid regular expression is rejected", func(t *testing.T) {
+		query := models.MetricBaseQuery{Dimensions: []models.Dimension{{Key: "machine", Value: "press-(", Operator: models.DimensionOperatorRegex}}}
+		_, err := ExpandOperators(context.TODO(), m, query, 10)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
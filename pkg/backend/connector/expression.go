@@ -0,0 +1,229 @@
+package connector
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/expression"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+)
+
+// queryExpression is the parsed expression of a query
+type queryExpression struct {
+	expr      *expression.Expression
+	name      string
+	tolerance time.Duration
+	// hidden are the metrics which are only retrieved to evaluate the expression
+	hidden map[string]bool
+}
+
+// parseExpression parses the expression of a query; it returns nil if the query does not have an expression
+func parseExpression(query models.MetricBaseQuery) (*queryExpression, error) {
+	if strings.TrimSpace(query.Expression) == "" {
+		return nil, nil
+	}
+	expr, err := expression.Parse(query.Expression)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "expression: %s", err)
+	}
+	if len(expr.Metrics()) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "expression: the expression does not reference a metric")
+	}
+	tolerance, err := models.ParseDuration(query.JoinTolerance)
+	if err != nil || tolerance < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "join tolerance: invalid duration %q", query.JoinTolerance)
+	}
+	name := query.ExpressionName
+	if name == "" {
+		name = strings.TrimSpace(query.Expression)
+	}
+	return &queryExpression{
+		expr:      expr,
+		name:      name,
+		tolerance: tolerance,
+		hidden:    map[string]bool{},
+	}, nil
+}
+
+// withMetrics returns the query with the metrics which are referenced by the expression
+func (e *queryExpression) withMetrics(query models.MetricBaseQuery) models.MetricBaseQuery {
+	if e == nil {
+		return query
+	}
+	query.Metrics = append([]models.Metric{}, query.Metrics...)
+	for _, id := range e.expr.Metrics() {
+		exists := false
+		for _, m := range query.Metrics {
+			exists = exists || m.MetricId == id
+		}
+		if !exists {
+			query.Metrics = append(query.Metrics, models.Metric{MetricId: id})
+			e.hidden[id] = true
+		}
+	}
+	return query
+}
+
+// series are the values of a numeric field of a metric frame
+type series struct {
+	labels []*pb.Label
+	times  []int64
+	values []float64
+}
+
+// newSeries returns the series of a metric frame by the labels of its numeric fields. A metric with more than one
+// numeric field with the same labels can not be used in an expression, as it is not clear which field is meant.
+func newSeries(frame *pb.Frame) (map[string]series, []string, error) {
+	times := make([]int64, len(frame.Timestamps))
+	for i, ts := range frame.Timestamps {
+		times[i] = ts.AsTime().UnixNano()
+	}
+	res := map[string]series{}
+	var keys []string
+	for _, fld := range frame.Fields {
+		if len(fld.StringValues) > 0 || len(fld.Values) != len(frame.Timestamps) {
+			continue
+		}
+		key := labelsKey(fld.Labels)
+		if _, exists := res[key]; exists {
+			return nil, nil, status.Errorf(codes.InvalidArgument, "expression: metric %q has more than one series with labels %s", frame.Metric, key)
+		}
+		res[key] = series{labels: fld.Labels, times: times, values: fld.Values}
+		keys = append(keys, key)
+	}
+	return res, keys, nil
+}
+
+// labelsKey returns a key which identifies the labels of a series
+func labelsKey(labels []*pb.Label) string {
+	return fieldKey(&pb.Field{Labels: labels})
+}
+
+// lookup returns the series of a metric with the given labels. A metric with a single series is used for all labels,
+// e.g. a total which is not split by the labels of the other metrics.
+func lookup(metric map[string]series, key string) series {
+	if s, ok := metric[key]; ok {
+		return s
+	}
+	if len(metric) == 1 {
+		for _, s := range metric {
+			return s
+		}
+	}
+	return series{}
+}
+
+// at returns the value which is nearest to t within the tolerance, or NaN if there is no such value
+func (s series) at(t int64, tolerance time.Duration) float64 {
+	i := sort.Search(len(s.times), func(i int) bool { return s.times[i] >= t })
+	best, bestDiff := math.NaN(), int64(tolerance)+1
+	for _, j := range []int{i - 1, i} {
+		if j < 0 || j >= len(s.times) {
+			continue
+		}
+		diff := s.times[j] - t
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff < bestDiff {
+			best, bestDiff = s.values[j], diff
+		}
+	}
+	return best
+}
+
+// evaluate returns the frames with the frame of the expression. The expression is evaluated for each series (numeric
+// field) of the first metric of the expression; the other metrics are matched on the labels of the series. The
+// timestamps of the expression frame are the timestamps of the first metric; the other metrics are joined on the value
+// which is nearest in time within the join tolerance. A missing value (NaN) results in a missing value.
+// The frames of the metrics which are only retrieved for the expression are removed.
+func (e *queryExpression) evaluate(frames []*pb.Frame) ([]*pb.Frame, error) {
+	if e == nil || frames == nil || len(e.expr.Metrics()) == 0 {
+		return frames, nil
+	}
+	metrics := map[string]map[string]series{}
+	var anchor *pb.Frame
+	var anchorKeys []string
+	var res []*pb.Frame
+	for _, frame := range frames {
+		metricSeries, keys, err := newSeries(frame)
+		if err != nil {
+			return nil, err
+		}
+		metrics[frame.Metric] = metricSeries
+		if frame.Metric == e.expr.Metrics()[0] {
+			anchor, anchorKeys = frame, keys
+		}
+		if !e.hidden[frame.Metric] {
+			res = append(res, frame)
+		}
+	}
+
+	result := &pb.Frame{Metric: e.name}
+	if len(anchorKeys) > 0 {
+		result.Timestamps = anchor.Timestamps
+	}
+	for _, key := range anchorKeys {
+		anchorSeries := metrics[anchor.Metric][key]
+		fld := &pb.Field{Name: e.name, Labels: anchorSeries.labels, Values: make([]float64, len(anchorSeries.times))}
+		for i, t := range anchorSeries.times {
+			fld.Values[i] = e.expr.Evaluate(func(metric string) float64 {
+				return lookup(metrics[metric], key).at(t, e.tolerance)
+			})
+		}
+		result.Fields = append(result.Fields, fld)
+	}
+	if len(result.Fields) == 0 {
+		result.Fields = []*pb.Field{{Name: e.name}}
+	}
+	return append(res, result), nil
+}
+
+// evaluateValues is the equivalent of evaluate for the frames of a metric value response
+func (e *queryExpression) evaluateValues(frames []*pb.GetMetricValueResponse_Frame) ([]*pb.GetMetricValueResponse_Frame, error) {
+	if e == nil {
+		return frames, nil
+	}
+	seriesFrames := make([]*pb.Frame, len(frames))
+	for i, frame := range frames {
+		seriesFrame := &pb.Frame{Metric: frame.Metric, Timestamps: []*timestamppb.Timestamp{frame.Timestamp}}
+		for _, fld := range frame.Fields {
+			seriesFld := &pb.Field{Name: fld.Name, Labels: fld.Labels}
+			if fld.StringValue != "" {
+				seriesFld.StringValues = []string{fld.StringValue}
+			} else {
+				seriesFld.Values = []float64{fld.Value}
+			}
+			seriesFrame.Fields = append(seriesFrame.Fields, seriesFld)
+		}
+		seriesFrames[i] = seriesFrame
+	}
+
+	var res []*pb.GetMetricValueResponse_Frame
+	for _, frame := range frames {
+		if !e.hidden[frame.Metric] {
+			res = append(res, frame)
+		}
+	}
+	result, err := e.evaluate(seriesFrames)
+	if err != nil {
+		return nil, err
+	}
+	if expr := result[len(result)-1]; len(expr.Timestamps) > 0 {
+		valueFrame := &pb.GetMetricValueResponse_Frame{Metric: expr.Metric, Timestamp: expr.Timestamps[0]}
+		for _, fld := range expr.Fields {
+			if len(fld.Values) > 0 {
+				valueFrame.Fields = append(valueFrame.Fields, &pb.SingleValueField{Name: fld.Name, Labels: fld.Labels, Value: fld.Values[0]})
+			}
+		}
+		res = append(res, valueFrame)
+	}
+	return res, nil
+}
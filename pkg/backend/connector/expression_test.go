@@ -0,0 +1,158 @@
+package connector
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/expression"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetMetricHistory_Expression(t *testing.T) {
+	nan := math.NaN()
+	m := &clientMock{}
+	m.On("GetMetricHistory", mock.Anything, "").Return(&v5.GetMetricHistoryResponse{
+		Frames: []*v5.Frame{
+			{Metric: "good_parts", Timestamps: timestamps(0, 10, 20), Fields: []*v5.Field{{Name: "value", Values: []float64{45, 40, 30}}}},
+			{Metric: "total_parts", Timestamps: timestamps(1, 11, 25), Fields: []*v5.Field{{Name: "value", Values: []float64{50, 50, 50}}}},
+		},
+	}, nil)
+
+	query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{
+		Metrics:        []models.Metric{{MetricId: "good_parts"}},
+		Expression:     "good_parts / total_parts * 100",
+		ExpressionName: "yield",
+		JoinTolerance:  "2s",
+	}}
+	res, err := GetMetricHistory(context.TODO(), m, query)
+	assert.NoError(t, err)
+
+	frames := res.GetFrames()
+	metrics := lo.Map(frames, func(f *v5.Frame, _ int) string { return f.Metric })
+	assert.Equal(t, []string{"good_parts", "yield"}, metrics, "the metrics which are only used by the expression are removed")
+
+	yield := frames[1]
+	assert.Equal(t, []int64{0, 10, 20}, seconds(yield.Timestamps))
+	assert.Equal(t, "yield", yield.Fields[0].Name)
+	assertValues(t, []float64{90, 80, nan}, yield.Fields[0].Values)
+}
+
+func TestEvaluateExpression(t *testing.T) {
+	nan := math.NaN()
+	zoneA := []*v5.Label{{Key: "zone", Value: "a"}}
+	zoneB := []*v5.Label{{Key: "zone", Value: "b"}}
+	parse := func(text string) *queryExpression {
+		expr, err := parseExpression(models.MetricBaseQuery{Expression: text, ExpressionName: "result"})
+		assert.NoError(t, err)
+		return expr
+	}
+
+	t.Run("should evaluate each series of the first metric with the series of the same labels", func(t *testing.T) {
+		res, err := parse("power_in - power_out").evaluate([]*v5.Frame{
+			{Metric: "power_in", Timestamps: timestamps(0, 10), Fields: []*v5.Field{
+				{Name: "value", Labels: zoneA, Values: []float64{10, 20}},
+				{Name: "value", Labels: zoneB, Values: []float64{30, 40}},
+			}},
+			{Metric: "power_out", Timestamps: timestamps(0, 10), Fields: []*v5.Field{
+				{Name: "value", Labels: zoneB, Values: []float64{3, 4}},
+				{Name: "value", Labels: zoneA, Values: []float64{1, 2}},
+			}},
+		})
+		assert.NoError(t, err)
+		result := res[len(res)-1]
+		assert.Equal(t, []int64{0, 10}, seconds(result.Timestamps))
+		if assert.Len(t, result.Fields, 2) {
+			assert.Equal(t, zoneA, result.Fields[0].Labels)
+			assertValues(t, []float64{9, 18}, result.Fields[0].Values)
+			assert.Equal(t, zoneB, result.Fields[1].Labels)
+			assertValues(t, []float64{27, 36}, result.Fields[1].Values)
+		}
+	})
+
+	t.Run("should use a metric with a single series for each series", func(t *testing.T) {
+		res, err := parse("power / total").evaluate([]*v5.Frame{
+			{Metric: "power", Timestamps: timestamps(0), Fields: []*v5.Field{
+				{Name: "value", Labels: zoneA, Values: []float64{10}},
+				{Name: "value", Labels: zoneB, Values: []float64{30}},
+			}},
+			{Metric: "total", Timestamps: timestamps(0), Fields: []*v5.Field{{Name: "value", Values: []float64{40}}}},
+		})
+		assert.NoError(t, err)
+		result := res[len(res)-1]
+		assertValues(t, []float64{0.25}, result.Fields[0].Values)
+		assertValues(t, []float64{0.75}, result.Fields[1].Values)
+	})
+
+	t.Run("should propagate missing values", func(t *testing.T) {
+		res, err := parse("power_in - power_out").evaluate([]*v5.Frame{
+			{Metric: "power_in", Timestamps: timestamps(0, 10, 20), Fields: []*v5.Field{{Name: "value", Labels: zoneA, Values: []float64{10, nan, 30}}}},
+			{Metric: "power_out", Timestamps: timestamps(0, 10, 20), Fields: []*v5.Field{
+				{Name: "value", Labels: zoneA, Values: []float64{1, 2, nan}},
+				{Name: "value", Labels: zoneB, Values: []float64{1, 2, 3}},
+			}},
+		})
+		assert.NoError(t, err)
+		assertValues(t, []float64{9, nan, nan}, res[len(res)-1].Fields[0].Values)
+	})
+
+	t.Run("should reject a metric with more than one series with the same labels", func(t *testing.T) {
+		_, err := parse("power_in - power_out").evaluate([]*v5.Frame{
+			{Metric: "power_in", Timestamps: timestamps(0), Fields: []*v5.Field{
+				{Name: "min", Values: []float64{1}},
+				{Name: "max", Values: []float64{2}},
+			}},
+			{Metric: "power_out", Timestamps: timestamps(0), Fields: []*v5.Field{{Name: "value", Values: []float64{1}}}},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "power_in")
+	})
+}
+
+func TestParseExpression(t *testing.T) {
+	t.Run("should add the referenced metrics to the query", func(t *testing.T) {
+		expr, err := parseExpression(models.MetricBaseQuery{Expression: "power_in - power_out"})
+		assert.NoError(t, err)
+
+		query := models.MetricBaseQuery{Metrics: []models.Metric{{MetricId: "power_in"}}}
+		res := expr.withMetrics(query)
+		assert.Equal(t, []models.Metric{{MetricId: "power_in"}, {MetricId: "power_out"}}, res.Metrics)
+		assert.Len(t, query.Metrics, 1)
+	})
+
+	t.Run("should use the expression as the default name", func(t *testing.T) {
+		expr, err := parseExpression(models.MetricBaseQuery{Expression: " power_in - power_out "})
+		assert.NoError(t, err)
+		assert.Equal(t, "power_in - power_out", expr.name)
+	})
+
+	t.Run("should report a syntax error", func(t *testing.T) {
+		_, err := parseExpression(models.MetricBaseQuery{Expression: "power_in -"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "position 11")
+	})
+
+	t.Run("should reject an expression without metrics", func(t *testing.T) {
+		_, err := parseExpression(models.MetricBaseQuery{Expression: "100"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Contains(t, err.Error(), "does not reference a metric")
+
+		constant, err := expression.Parse("100")
+		assert.NoError(t, err)
+		frames := []*v5.Frame{{Metric: "power_in"}}
+		res, err := (&queryExpression{expr: constant}).evaluate(frames)
+		assert.NoError(t, err)
+		assert.Equal(t, frames, res)
+	})
+
+	t.Run("should reject an invalid join tolerance", func(t *testing.T) {
+		_, err := parseExpression(models.MetricBaseQuery{Expression: "power_in", JoinTolerance: "soon"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
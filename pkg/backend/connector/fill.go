@@ -0,0 +1,194 @@
+package connector
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+)
+
+// maxFilledBuckets prevents that a very small interval results in a huge number of buckets
+const maxFilledBuckets = 100_000
+
+// fillGaps inserts the buckets which are missing between the start and end of the time range of an aggregate query.
+// The buckets follow the calendar intervals of the query, or the query interval which is aligned to the buckets
+// returned by the server. The values of the inserted buckets are determined by the fill mode of the query.
+// A requested metric without a frame in the result gets a frame of which all buckets are filled.
+func fillGaps(frames []*pb.Frame, query models.MetricAggregateQuery) []*pb.Frame {
+	if query.FillMode == models.FillModeNone {
+		return frames
+	}
+	frames = append(frames, missingFrames(frames, query.Metrics)...)
+	for i, frame := range frames {
+		buckets := missingBuckets(frame, query)
+		if len(buckets) == 0 {
+			continue
+		}
+		frames[i] = fillFrame(frame, buckets, query.FillMode)
+	}
+	return frames
+}
+
+// missingFrames returns a frame without buckets for each requested metric which is not present in the frames
+func missingFrames(frames []*pb.Frame, metrics []models.Metric) []*pb.Frame {
+	present := make(map[string]bool, len(frames))
+	for _, frame := range frames {
+		present[frame.Metric] = true
+	}
+	var res []*pb.Frame
+	for _, m := range metrics {
+		if present[m.MetricId] {
+			continue
+		}
+		present[m.MetricId] = true
+		res = append(res, &pb.Frame{Metric: m.MetricId, Fields: []*pb.Field{{}}})
+	}
+	return res
+}
+
+// missingBuckets returns the start of the buckets which are missing in an (ascending) frame
+func missingBuckets(frame *pb.Frame, query models.MetricAggregateQuery) []time.Time {
+	from, to := query.TimeRange.From, query.TimeRange.To
+	if !from.Before(to) {
+		return nil
+	}
+
+	existing := make(map[int64]bool, len(frame.Timestamps))
+	for _, ts := range frame.Timestamps {
+		existing[ts.AsTime().UnixNano()] = true
+	}
+
+	var res []time.Time
+	if query.CalendarInterval != models.CalendarIntervalNone {
+		loc, err := time.LoadLocation(query.TimeZone)
+		if err != nil {
+			return nil
+		}
+		for _, t := range query.CalendarInterval.Intervals(from, to, loc) {
+			if !existing[t.UnixNano()] {
+				res = append(res, t)
+			}
+		}
+		return res
+	}
+
+	interval := query.Interval
+	if interval <= 0 || to.Sub(from)/interval > maxFilledBuckets {
+		return nil
+	}
+	if len(frame.Timestamps) == 0 {
+		// without buckets of the server, the buckets are aligned to the interval
+		for t := from.Truncate(interval); t.Before(to); t = t.Add(interval) {
+			if !t.Before(from) {
+				res = append(res, t)
+			}
+		}
+		return res
+	}
+	first := frame.Timestamps[0].AsTime()
+	for t := first.Add(-interval); !t.Before(from); t = t.Add(-interval) {
+		res = append(res, t)
+	}
+	for i, ts := range frame.Timestamps {
+		end := to
+		if i+1 < len(frame.Timestamps) {
+			// a bucket is missing if the next bucket starts more than half an interval after it
+			end = frame.Timestamps[i+1].AsTime().Add(-interval / 2)
+		}
+		for t := ts.AsTime().Add(interval); t.Before(end); t = t.Add(interval) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// fillFrame returns a frame with the inserted buckets; the values of inserted buckets which are not filled are null
+func fillFrame(frame *pb.Frame, buckets []time.Time, mode models.FillMode) *pb.Frame {
+	type row struct {
+		ts time.Time
+		// index is the row of the original frame, or -1 for an inserted bucket
+		index int
+	}
+	rows := make([]row, 0, len(frame.Timestamps)+len(buckets))
+	for i, ts := range frame.Timestamps {
+		rows = append(rows, row{ts: ts.AsTime(), index: i})
+	}
+	for _, t := range buckets {
+		rows = append(rows, row{ts: t, index: -1})
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].ts.Before(rows[j].ts) })
+
+	res := &pb.Frame{
+		Metric:     frame.Metric,
+		Meta:       frame.Meta,
+		Timestamps: make([]*timestamppb.Timestamp, len(rows)),
+	}
+	for i, r := range rows {
+		if r.index >= 0 {
+			res.Timestamps[i] = frame.Timestamps[r.index]
+		} else {
+			res.Timestamps[i] = timestamppb.New(r.ts)
+		}
+	}
+
+	// next is the next row of the original frame for each row
+	next := make([]int, len(rows))
+	for i, n := len(rows)-1, -1; i >= 0; i-- {
+		next[i] = n
+		if rows[i].index >= 0 {
+			n = i
+		}
+	}
+
+	for _, fld := range frame.Fields {
+		newField := &pb.Field{Name: fld.Name, Labels: fld.Labels, Config: fld.Config}
+		if len(fld.StringValues) > 0 {
+			// an inserted string is missing (empty), unless the previous value is repeated
+			newField.StringValues = make([]string, len(rows))
+			previous := ""
+			for i, r := range rows {
+				if r.index >= 0 && r.index < len(fld.StringValues) {
+					previous = fld.StringValues[r.index]
+					newField.StringValues[i] = previous
+				} else if mode == models.FillModePrevious {
+					newField.StringValues[i] = previous
+				}
+			}
+			res.Fields = append(res.Fields, newField)
+			continue
+		}
+
+		newField.Values = make([]float64, len(rows))
+		value := func(i int) float64 {
+			if idx := rows[i].index; idx < len(fld.Values) {
+				return fld.Values[idx]
+			}
+			return math.NaN()
+		}
+		prev := -1
+		for i, r := range rows {
+			if r.index >= 0 {
+				newField.Values[i] = value(i)
+				prev = i
+				continue
+			}
+			switch {
+			case mode == models.FillModeZero:
+				newField.Values[i] = 0
+			case mode == models.FillModePrevious && prev >= 0:
+				newField.Values[i] = value(prev)
+			case mode == models.FillModeLinear && prev >= 0 && next[i] >= 0:
+				v0, v1 := value(prev), value(next[i])
+				newField.Values[i] = v0 + (v1-v0)*float64(r.ts.Sub(rows[prev].ts))/float64(rows[next[i]].ts.Sub(rows[prev].ts))
+			default:
+				newField.Values[i] = math.NaN()
+			}
+		}
+		res.Fields = append(res.Fields, newField)
+	}
+	return res
+}
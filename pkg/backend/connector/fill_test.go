@@ -0,0 +1,110 @@
+package connector
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFillGaps(t *testing.T) {
+	nan := math.NaN()
+	zone := []*pb.Label{{Key: "zone", Value: "a"}}
+	config := &pb.Config{Unit: "kWh"}
+
+	// buckets of 10 seconds between 0 and 60; the buckets 0, 20, 30 and 50 are missing
+	frame := func() *pb.Frame {
+		return &pb.Frame{
+			Metric:     "output",
+			Timestamps: timestamps(10, 40),
+			Fields: []*pb.Field{
+				{Name: "sum", Labels: zone, Config: config, Values: []float64{1, 4}},
+				{Name: "state", StringValues: []string{"on", "off"}},
+			},
+		}
+	}
+	query := func(mode models.FillMode) models.MetricAggregateQuery {
+		return models.MetricAggregateQuery{
+			MetricBaseQuery: models.MetricBaseQuery{
+				TimeRange: backend.TimeRange{From: time.Unix(0, 0), To: time.Unix(60, 0)},
+				Interval:  10 * time.Second,
+			},
+			FillMode: mode,
+		}
+	}
+
+	// a missing value is NaN or an empty string, which the framer converts to null
+	tests := []struct {
+		mode   models.FillMode
+		values []float64
+		states []string
+	}{
+		{models.FillModeNull, []float64{nan, 1, nan, nan, 4, nan}, []string{"", "on", "", "", "off", ""}},
+		{models.FillModeZero, []float64{0, 1, 0, 0, 4, 0}, []string{"", "on", "", "", "off", ""}},
+		{models.FillModePrevious, []float64{nan, 1, 1, 1, 4, 4}, []string{"", "on", "on", "on", "off", "off"}},
+		{models.FillModeLinear, []float64{nan, 1, 2, 3, 4, nan}, []string{"", "on", "", "", "off", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			res := fillGaps([]*pb.Frame{frame()}, query(tt.mode))[0]
+			assert.Equal(t, []int64{0, 10, 20, 30, 40, 50}, seconds(res.Timestamps))
+			assertValues(t, tt.values, res.Fields[0].Values)
+			assert.Equal(t, tt.states, res.Fields[1].StringValues)
+			assert.Equal(t, zone, res.Fields[0].Labels)
+			assert.Equal(t, config, res.Fields[0].Config)
+		})
+	}
+
+	t.Run("frames are not modified without a fill mode", func(t *testing.T) {
+		res := fillGaps([]*pb.Frame{frame()}, query(models.FillModeNone))[0]
+		assert.Equal(t, []int64{10, 40}, seconds(res.Timestamps))
+	})
+
+	t.Run("a frame without buckets is filled from the time range", func(t *testing.T) {
+		f := &pb.Frame{Metric: "output", Fields: []*pb.Field{{Name: "sum"}}}
+		res := fillGaps([]*pb.Frame{f}, query(models.FillModeNull))[0]
+		assert.Equal(t, []int64{0, 10, 20, 30, 40, 50}, seconds(res.Timestamps))
+		assertValues(t, []float64{nan, nan, nan, nan, nan, nan}, res.Fields[0].Values)
+	})
+
+	t.Run("a requested metric without a frame is filled from the time range", func(t *testing.T) {
+		q := query(models.FillModeZero)
+		q.Metrics = []models.Metric{{MetricId: "output"}, {MetricId: "input"}}
+		res := fillGaps([]*pb.Frame{frame()}, q)
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, "input", res[1].Metric)
+			assert.Equal(t, []int64{0, 10, 20, 30, 40, 50}, seconds(res[1].Timestamps))
+			assertValues(t, []float64{0, 0, 0, 0, 0, 0}, res[1].Fields[0].Values)
+		}
+	})
+
+	t.Run("the buckets are aligned to the buckets of the server", func(t *testing.T) {
+		f := frame()
+		f.Timestamps = timestamps(15, 45)
+		res := fillGaps([]*pb.Frame{f}, query(models.FillModeNull))[0]
+		assert.Equal(t, []int64{5, 15, 25, 35, 45, 55}, seconds(res.Timestamps))
+	})
+
+	t.Run("the buckets follow the calendar interval", func(t *testing.T) {
+		amsterdam, _ := time.LoadLocation("Europe/Amsterdam")
+		day := func(d int) time.Time { return time.Date(2024, 10, d, 0, 0, 0, 0, amsterdam) }
+		q := models.MetricAggregateQuery{
+			MetricBaseQuery: models.MetricBaseQuery{
+				TimeRange: backend.TimeRange{From: day(26), To: day(29)},
+				Interval:  time.Hour,
+				TimeZone:  "Europe/Amsterdam",
+			},
+			CalendarInterval: models.CalendarIntervalDay,
+			FillMode:         models.FillModeZero,
+		}
+		f := &pb.Frame{Metric: "output", Timestamps: timestamps(day(26).Unix(), day(28).Unix()), Fields: []*pb.Field{{Name: "sum", Values: []float64{1, 3}}}}
+
+		res := fillGaps([]*pb.Frame{f}, q)[0]
+		assert.Equal(t, []int64{day(26).Unix(), day(27).Unix(), day(28).Unix()}, seconds(res.Timestamps))
+		assertValues(t, []float64{1, 0, 3}, res.Fields[0].Values)
+	})
+}
@@ -2,67 +2,109 @@ package connector
 
 import (
 	"context"
+	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/framer"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	"github.com/samber/lo"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
 func aggregateQueryToInput(query models.MetricAggregateQuery) (*pb.GetMetricAggregateRequest, error) {
-	var dimensions []*pb.Dimension
-	for _, d := range query.Dimensions {
-		dimensions = append(dimensions, &pb.Dimension{
-			Key:   d.Key,
-			Value: d.Value,
-		})
-	}
-
 	metrics := make([]string, len(query.Metrics))
 	for i := range query.Metrics {
 		metrics[i] = query.Metrics[i].MetricId
 	}
+	if _, err := time.LoadLocation(query.TimeZone); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid time zone %q", query.TimeZone)
+	}
+	options, err := optionsToInput(query.Options)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.GetMetricAggregateRequest{
-		IntervalMs:    query.Interval.Milliseconds(),
-		MaxItems:      query.MaxDataPoints,
-		Dimensions:    dimensions,
-		Metrics:       metrics,
-		StartDate:     timestamppb.New(query.TimeRange.From),
-		EndDate:       timestamppb.New(query.TimeRange.To),
-		StartingToken: query.NextToken,
-		Options:       lo.MapValues(query.Options, func(value models.OptionValue, key string) string { return value.Value }),
+		IntervalMs:       query.Interval.Milliseconds(),
+		CalendarInterval: calendarIntervalToInput(query.CalendarInterval),
+		TimeZone:         query.TimeZone,
+		MaxItems:         query.MaxDataPoints,
+		Dimensions:       dimensionsToInput(query.Dimensions),
+		Metrics:          metrics,
+		StartDate:        timestamppb.New(query.TimeRange.From),
+		EndDate:          timestamppb.New(query.TimeRange.To),
+		StartingToken:    query.NextToken,
+		Options:          options,
 	}, nil
 }
 
+func calendarIntervalToInput(interval models.CalendarInterval) pb.CalendarInterval {
+	switch interval {
+	case models.CalendarIntervalDay:
+		return pb.CalendarInterval_CALENDAR_INTERVAL_DAY
+	case models.CalendarIntervalWeek:
+		return pb.CalendarInterval_CALENDAR_INTERVAL_WEEK
+	case models.CalendarIntervalMonth:
+		return pb.CalendarInterval_CALENDAR_INTERVAL_MONTH
+	case models.CalendarIntervalQuarter:
+		return pb.CalendarInterval_CALENDAR_INTERVAL_QUARTER
+	default:
+		return pb.CalendarInterval_CALENDAR_INTERVAL_NONE
+	}
+}
+
+// GetMetricAggregate retrieves all pages of the metric aggregates. If a page fails for a query which accepts partial results,
+// the result contains the frames of the preceding pages and a *PartialResultError is returned.
+// For a time shifted query, or a query with a comparison period, the timestamps are moved back onto the time range of the query.
 func GetMetricAggregate(ctx context.Context, client client.BackendAPIClient, query models.MetricAggregateQuery) (*framer.MetricAggregate, error) {
-	clientReq, err := aggregateQueryToInput(query)
+	periods, err := queryPeriods(query.MetricBaseQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	frames := map[string]*pb.Frame{}
-	for {
-		resp, err := client.GetMetricAggregate(ctx, clientReq)
+	res := &framer.MetricAggregate{
+		GetMetricAggregateResponse: &pb.GetMetricAggregateResponse{},
+		Query:                      query.MetricBaseQuery,
+	}
+	for _, p := range periods {
+		shifted := query
+		shifted.MetricBaseQuery = p.apply(query.MetricBaseQuery)
 
-		if err != nil {
+		frames, notices, err := getMetricAggregate(ctx, client, shifted)
+		if frames == nil && err != nil {
 			return nil, err
 		}
+		p.restore(frames)
+		res.GetMetricAggregateResponse.Frames = append(res.GetMetricAggregateResponse.Frames, frames...)
+		res.Notices = append(res.Notices, notices...)
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
 
-		appendMatchingFrames(frames, resp.Frames)
+func getMetricAggregate(ctx context.Context, client client.BackendAPIClient, query models.MetricAggregateQuery) ([]*pb.Frame, []data.Notice, error) {
+	expr, err := parseExpression(query.MetricBaseQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	query.MetricBaseQuery = expr.withMetrics(query.MetricBaseQuery)
+	clientReq, err := aggregateQueryToInput(query)
+	if err != nil {
+		return nil, nil, err
+	}
 
-		if resp != nil && resp.NextToken != "" {
-			clientReq.StartingToken = resp.NextToken
-			continue
-		}
-		break
+	frames, notices, err := paginate(ctx, query.MetricBaseQuery, itemLimit{}, func(ctx context.Context, token string) (page, error) {
+		clientReq.StartingToken = token
+		return client.GetMetricAggregate(ctx, clientReq)
+	})
+	result, evalErr := expr.evaluate(fillGaps(frames, query))
+	if evalErr != nil {
+		return nil, nil, evalErr
 	}
-	return &framer.MetricAggregate{
-		GetMetricAggregateResponse: &pb.GetMetricAggregateResponse{
-			Frames: lo.MapToSlice(frames, func(_ string, v *pb.Frame) *pb.Frame { return v }),
-		},
-		Query: query.MetricBaseQuery,
-	}, nil
+	return result, notices, err
 }
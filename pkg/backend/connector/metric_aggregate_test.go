@@ -0,0 +1,97 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Gets the aggregates for one or more metrics
+func (clientmock *clientMock) GetMetricAggregate(ctx context.Context, in *v5.GetMetricAggregateRequest, opts ...grpc.CallOption) (*v5.GetMetricAggregateResponse, error) {
+	args := clientmock.Called(ctx, in.StartingToken)
+	if v, ok := args.Get(0).(*v5.GetMetricAggregateResponse); ok {
+		return v, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func aggregatePage(i int, nextToken string) *v5.GetMetricAggregateResponse {
+	return &v5.GetMetricAggregateResponse{
+		Frames: []*v5.Frame{
+			{
+				Metric:     "temperature",
+				Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Unix(int64(i), 0))},
+				Fields:     []*v5.Field{{Name: "value", Values: []float64{float64(i)}}},
+			},
+		},
+		NextToken: nextToken,
+	}
+}
+
+func TestGetMetricAggregate_PartialResults(t *testing.T) {
+	failingClient := func() *clientMock {
+		m := &clientMock{}
+		m.On("GetMetricAggregate", mock.Anything, "").Return(aggregatePage(0, "1"), nil)
+		m.On("GetMetricAggregate", mock.Anything, "1").Return(aggregatePage(1, "2"), nil)
+		m.On("GetMetricAggregate", mock.Anything, "2").Return(nil, status.Error(codes.Unavailable, "connection reset"))
+		return m
+	}
+
+	t.Run("should fail without partial results", func(t *testing.T) {
+		res, err := GetMetricAggregate(context.TODO(), failingClient(), models.MetricAggregateQuery{})
+		assert.Nil(t, res)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("should return the frames of the preceding pages", func(t *testing.T) {
+		query := models.MetricAggregateQuery{MetricBaseQuery: models.MetricBaseQuery{PartialResults: true}}
+		res, err := GetMetricAggregate(context.TODO(), failingClient(), query)
+
+		var partial *PartialResultError
+		if assert.True(t, errors.As(err, &partial)) {
+			assert.Equal(t, 3, partial.Page)
+			assert.Equal(t, codes.Unavailable, status.Code(partial.Err))
+		}
+		if assert.NotNil(t, res) {
+			assert.Len(t, res.GetFrames()[0].Timestamps, 2)
+			if assert.Len(t, res.Notices, 1) {
+				assert.Equal(t, data.NoticeSeverityError, res.Notices[0].Severity)
+				assert.Contains(t, res.Notices[0].Text, "Page 3 failed with Unavailable")
+			}
+		}
+	})
+
+	t.Run("should fail if the first page fails", func(t *testing.T) {
+		m := &clientMock{}
+		m.On("GetMetricAggregate", mock.Anything, "").Return(nil, status.Error(codes.Unavailable, "connection reset"))
+
+		query := models.MetricAggregateQuery{MetricBaseQuery: models.MetricBaseQuery{PartialResults: true}}
+		res, err := GetMetricAggregate(context.TODO(), m, query)
+		assert.Nil(t, res)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+func Test_aggregateQueryToInput_CalendarInterval(t *testing.T) {
+	t.Run("should pass the calendar interval and time zone", func(t *testing.T) {
+		req, err := aggregateQueryToInput(models.MetricAggregateQuery{MetricBaseQuery: models.MetricBaseQuery{TimeZone: "Europe/Amsterdam"}, CalendarInterval: models.CalendarIntervalMonth})
+		assert.NoError(t, err)
+		assert.Equal(t, v5.CalendarInterval_CALENDAR_INTERVAL_MONTH, req.CalendarInterval)
+		assert.Equal(t, "Europe/Amsterdam", req.TimeZone)
+	})
+	t.Run("should reject an unknown time zone", func(t *testing.T) {
+		_, err := aggregateQueryToInput(models.MetricAggregateQuery{MetricBaseQuery: models.MetricBaseQuery{TimeZone: "Plant/Local"}, CalendarInterval: models.CalendarIntervalDay})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -3,61 +3,94 @@ package connector
 import (
 	"context"
 
-	"github.com/samber/lo"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/framer"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 )
 
-func historyQueryToInput(query models.MetricHistoryQuery) *pb.GetMetricHistoryRequest {
-	var dimensions []*pb.Dimension
-	for _, d := range query.Dimensions {
-		dimensions = append(dimensions, &pb.Dimension{
-			Key:   d.Key,
-			Value: d.Value,
-		})
-	}
+func historyQueryToInput(query models.MetricHistoryQuery) (*pb.GetMetricHistoryRequest, error) {
 	metrics := make([]string, len(query.Metrics))
 	for i := range query.Metrics {
 		metrics[i] = query.Metrics[i].MetricId
 	}
+	options, err := optionsToInput(query.Options)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.GetMetricHistoryRequest{
-		Dimensions:    dimensions,
+		Dimensions:    dimensionsToInput(query.Dimensions),
 		Metrics:       metrics,
 		StartDate:     timestamppb.New(query.TimeRange.From),
 		EndDate:       timestamppb.New(query.TimeRange.To),
+		MaxItems:      query.MaxItems,
+		TimeOrdering:  timeOrderingToInput(query.TimeOrdering),
 		StartingToken: query.NextToken,
-		Options:       lo.MapValues(query.Options, func(value models.OptionValue, key string) string { return value.Value }),
+		Options:       options,
+	}, nil
+}
+
+func timeOrderingToInput(ordering models.TimeOrdering) pb.TimeOrdering {
+	if ordering == models.TimeOrderingDescending {
+		return pb.TimeOrdering_DESCENDING
 	}
+	return pb.TimeOrdering_ASCENDING
 }
 
+// GetMetricHistory retrieves all pages of the metric history. If a page fails for a query which accepts partial results,
+// the result contains the frames of the preceding pages and a *PartialResultError is returned.
+// The values of the frames are always sorted in ascending time order, regardless of the time ordering of the query.
+// For a time shifted query, or a query with a comparison period, the timestamps are moved back onto the time range of the query.
 func GetMetricHistory(ctx context.Context, client client.BackendAPIClient, query models.MetricHistoryQuery) (*framer.MetricHistory, error) {
-	clientReq := historyQueryToInput(query)
+	periods, err := queryPeriods(query.MetricBaseQuery)
+	if err != nil {
+		return nil, err
+	}
 
-	frames := map[string]*pb.Frame{}
-	for {
-		resp, err := client.GetMetricHistory(ctx, clientReq)
+	res := &framer.MetricHistory{
+		GetMetricHistoryResponse: &pb.GetMetricHistoryResponse{},
+		Query:                    query,
+	}
+	for _, p := range periods {
+		shifted := query
+		shifted.MetricBaseQuery = p.apply(query.MetricBaseQuery)
 
-		if err != nil {
+		frames, notices, err := getMetricHistory(ctx, client, shifted)
+		if frames == nil && err != nil {
 			return nil, err
 		}
-
-		appendMatchingFrames(frames, resp.Frames)
-
-		if resp != nil && resp.NextToken != "" {
-			clientReq.StartingToken = resp.NextToken
-			continue
+		p.restore(frames)
+		res.GetMetricHistoryResponse.Frames = append(res.GetMetricHistoryResponse.Frames, frames...)
+		res.Notices = append(res.Notices, notices...)
+		if err != nil {
+			return res, err
 		}
-		break
 	}
+	return res, nil
+}
 
-	return &framer.MetricHistory{
-		GetMetricHistoryResponse: &pb.GetMetricHistoryResponse{
-			Frames: lo.MapToSlice(frames, func(_ string, v *pb.Frame) *pb.Frame { return v }),
-		},
-		Query: query,
-	}, nil
+func getMetricHistory(ctx context.Context, client client.BackendAPIClient, query models.MetricHistoryQuery) ([]*pb.Frame, []data.Notice, error) {
+	expr, err := parseExpression(query.MetricBaseQuery)
+	if err != nil {
+		return nil, nil, err
+	}
+	query.MetricBaseQuery = expr.withMetrics(query.MetricBaseQuery)
+	clientReq, err := historyQueryToInput(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	items := itemLimit{max: query.MaxItems, ordering: query.TimeOrdering}
+	frames, notices, err := paginate(ctx, query.MetricBaseQuery, items, func(ctx context.Context, token string) (page, error) {
+		clientReq.StartingToken = token
+		return client.GetMetricHistory(ctx, clientReq)
+	})
+	result, evalErr := expr.evaluate(frames)
+	if evalErr != nil {
+		return nil, nil, evalErr
+	}
+	return downsampleFrames(result, query.Downsampling, query.MaxDataPoints), notices, err
 }
@@ -0,0 +1,206 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Gets the history for one or more metrics
+func (clientmock *clientMock) GetMetricHistory(ctx context.Context, in *v5.GetMetricHistoryRequest, opts ...grpc.CallOption) (*v5.GetMetricHistoryResponse, error) {
+	args := clientmock.Called(ctx, in.StartingToken)
+	if v, ok := args.Get(0).(*v5.GetMetricHistoryResponse); ok {
+		return v, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+// historyPage returns a page with a single value for the page index
+func historyPage(i int, nextToken string) *v5.GetMetricHistoryResponse {
+	return &v5.GetMetricHistoryResponse{
+		Frames: []*v5.Frame{
+			{
+				Metric:     "temperature",
+				Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Unix(int64(i), 0))},
+				Fields:     []*v5.Field{{Name: "value", Values: []float64{float64(i)}}},
+			},
+		},
+		NextToken: nextToken,
+	}
+}
+
+// paginatedHistoryMock returns a client which returns the specified number of pages
+func paginatedHistoryMock(pages int) *clientMock {
+	m := &clientMock{}
+	for i := 0; i < pages; i++ {
+		token, next := fmt.Sprint(i), fmt.Sprint(i+1)
+		if i == 0 {
+			token = ""
+		}
+		if i == pages-1 {
+			next = ""
+		}
+		m.On("GetMetricHistory", mock.Anything, token).Return(historyPage(i, next), nil)
+	}
+	return m
+}
+
+func TestGetMetricHistory_Limits(t *testing.T) {
+	t.Run("should retrieve all pages", func(t *testing.T) {
+		m := paginatedHistoryMock(10)
+		res, err := GetMetricHistory(context.TODO(), m, models.MetricHistoryQuery{})
+		assert.NoError(t, err)
+		assert.Len(t, res.GetFrames()[0].Timestamps, 10)
+		assert.Empty(t, res.Notices)
+	})
+	t.Run("should stop when the page limit is reached", func(t *testing.T) {
+		m := paginatedHistoryMock(10)
+		query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{Limits: models.QueryLimits{MaxPages: 3}}}
+
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Len(t, res.GetFrames()[0].Timestamps, 3)
+		if assert.Len(t, res.Notices, 1) {
+			assert.Equal(t, data.NoticeSeverityWarning, res.Notices[0].Severity)
+			assert.Contains(t, res.Notices[0].Text, "3 pages")
+		}
+	})
+	t.Run("should stop when the point limit is reached", func(t *testing.T) {
+		m := paginatedHistoryMock(10)
+		query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{Limits: models.QueryLimits{MaxPoints: 5}}}
+
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Len(t, res.GetFrames()[0].Timestamps, 5)
+		assert.Len(t, res.Notices, 1)
+	})
+	t.Run("should not add a notice if the limit is reached on the last page", func(t *testing.T) {
+		m := paginatedHistoryMock(3)
+		query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{Limits: models.QueryLimits{MaxPages: 3}}}
+
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Empty(t, res.Notices)
+	})
+	t.Run("should fail when the hard limit is exceeded", func(t *testing.T) {
+		m := paginatedHistoryMock(10)
+		query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{
+			Limits:     models.QueryLimits{MaxPages: 5},
+			HardLimits: models.QueryLimits{MaxBytes: 50},
+		}}
+
+		_, err := GetMetricHistory(context.TODO(), m, query)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+	t.Run("should not fail when the result is exactly at the hard limit", func(t *testing.T) {
+		query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{
+			HardLimits: models.QueryLimits{MaxPages: 10, MaxPoints: 10},
+		}}
+		res, err := GetMetricHistory(context.TODO(), paginatedHistoryMock(10), query)
+		assert.NoError(t, err)
+		assert.Len(t, res.GetFrames()[0].Timestamps, 10)
+
+		_, err = GetMetricHistory(context.TODO(), paginatedHistoryMock(11), query)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+}
+
+// descendingHistoryPage returns a page with the values of two consecutive seconds in descending order
+func descendingHistoryPage(i int, nextToken string) *v5.GetMetricHistoryResponse {
+	return &v5.GetMetricHistoryResponse{
+		Frames: []*v5.Frame{
+			{
+				Metric:     "temperature",
+				Timestamps: timestamps(int64(i), int64(i-1)),
+				Fields:     []*v5.Field{{Name: "value", Values: []float64{float64(i), float64(i - 1)}}},
+			},
+		},
+		NextToken: nextToken,
+	}
+}
+
+func TestGetMetricHistory_TimeOrdering(t *testing.T) {
+	t.Run("should pass the ordering and max items to the backend", func(t *testing.T) {
+		req, err := historyQueryToInput(models.MetricHistoryQuery{
+			TimeOrdering: models.TimeOrderingDescending,
+			MaxItems:     50,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, v5.TimeOrdering_DESCENDING, req.TimeOrdering)
+		assert.Equal(t, int64(50), req.MaxItems)
+		req, err = historyQueryToInput(models.MetricHistoryQuery{})
+		assert.NoError(t, err)
+		assert.Equal(t, v5.TimeOrdering_ASCENDING, req.TimeOrdering)
+	})
+
+	descendingMock := func() *clientMock {
+		m := &clientMock{}
+		m.On("GetMetricHistory", mock.Anything, "").Return(descendingHistoryPage(10, "1"), nil)
+		m.On("GetMetricHistory", mock.Anything, "1").Return(descendingHistoryPage(8, "2"), nil)
+		m.On("GetMetricHistory", mock.Anything, "2").Return(descendingHistoryPage(6, "3"), nil)
+		m.On("GetMetricHistory", mock.Anything, "3").Return(descendingHistoryPage(4, ""), nil)
+		return m
+	}
+
+	t.Run("should return the values in ascending order", func(t *testing.T) {
+		query := models.MetricHistoryQuery{TimeOrdering: models.TimeOrderingDescending}
+		res, err := GetMetricHistory(context.TODO(), descendingMock(), query)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{3, 4, 5, 6, 7, 8, 9, 10}, seconds(res.GetFrames()[0].Timestamps))
+		assert.Equal(t, []float64{3, 4, 5, 6, 7, 8, 9, 10}, res.GetFrames()[0].Fields[0].Values)
+	})
+
+	t.Run("should stop paginating when the max items are reached", func(t *testing.T) {
+		m := descendingMock()
+		query := models.MetricHistoryQuery{
+			MetricBaseQuery: models.MetricBaseQuery{Metrics: []models.Metric{{MetricId: "temperature"}}},
+			TimeOrdering:    models.TimeOrderingDescending,
+			MaxItems:        3,
+		}
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{8, 9, 10}, seconds(res.GetFrames()[0].Timestamps))
+		assert.Equal(t, []float64{8, 9, 10}, res.GetFrames()[0].Fields[0].Values)
+		m.AssertNumberOfCalls(t, "GetMetricHistory", 2)
+	})
+
+	t.Run("should return the oldest values for an ascending ordering", func(t *testing.T) {
+		m := paginatedHistoryMock(10)
+		query := models.MetricHistoryQuery{
+			MetricBaseQuery: models.MetricBaseQuery{Metrics: []models.Metric{{MetricId: "temperature"}}},
+			MaxItems:        4,
+		}
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{0, 1, 2, 3}, seconds(res.GetFrames()[0].Timestamps))
+		m.AssertNumberOfCalls(t, "GetMetricHistory", 4)
+	})
+
+	t.Run("should not drop a metric whose values are on a later page", func(t *testing.T) {
+		pressure := historyPage(5, "")
+		pressure.Frames[0].Metric = "pressure"
+		m := &clientMock{}
+		m.On("GetMetricHistory", mock.Anything, "").Return(descendingHistoryPage(10, "1"), nil)
+		m.On("GetMetricHistory", mock.Anything, "1").Return(pressure, nil)
+		query := models.MetricHistoryQuery{
+			MetricBaseQuery: models.MetricBaseQuery{Metrics: []models.Metric{{MetricId: "temperature"}, {MetricId: "pressure"}}},
+			TimeOrdering:    models.TimeOrderingDescending,
+			MaxItems:        2,
+		}
+		res, err := GetMetricHistory(context.TODO(), m, query)
+		assert.NoError(t, err)
+		assert.Len(t, res.GetFrames(), 2)
+		m.AssertNumberOfCalls(t, "GetMetricHistory", 2)
+	})
+}
@@ -6,43 +6,62 @@ import (
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/framer"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
-	"github.com/samber/lo"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func valueQueryToInput(query models.MetricValueQuery) *pb.GetMetricValueRequest {
-	var dimensions []*pb.Dimension
-	for _, d := range query.Dimensions {
-		dimensions = append(dimensions, &pb.Dimension{
-			Key:   d.Key,
-			Value: d.Value,
-		})
-	}
+func valueQueryToInput(query models.MetricValueQuery) (*pb.GetMetricValueRequest, error) {
 	metrics := make([]string, len(query.Metrics))
 	for i := range query.Metrics {
 		metrics[i] = query.Metrics[i].MetricId
 	}
+	options, err := optionsToInput(query.Options)
+	if err != nil {
+		return nil, err
+	}
 	return &pb.GetMetricValueRequest{
-		Options:    lo.MapValues(query.Options, func(value models.OptionValue, key string) string { return value.Value }),
-		Dimensions: dimensions,
+		Options:    options,
+		Dimensions: dimensionsToInput(query.Dimensions),
 		Metrics:    metrics,
 		StartDate:  timestamppb.New(query.TimeRange.From),
 		EndDate:    timestamppb.New(query.TimeRange.To),
-	}
+	}, nil
 }
 
+// GetMetricValue retrieves the values of the metrics. For a time shifted query, or a query with a comparison period,
+// the timestamps are moved back onto the time range of the query.
 func GetMetricValue(ctx context.Context, client client.BackendAPIClient, query models.MetricValueQuery) (*framer.MetricValue, error) {
-	clientReq := valueQueryToInput(query)
-
-	resp, err := client.GetMetricValue(ctx, clientReq)
-
+	periods, err := queryPeriods(query.MetricBaseQuery)
 	if err != nil {
 		return nil, err
 	}
 
-	return &framer.MetricValue{
-		GetMetricValueResponse: resp,
+	res := &framer.MetricValue{
+		GetMetricValueResponse: &pb.GetMetricValueResponse{},
 		Query:                  query,
-	}, nil
+	}
+	for _, p := range periods {
+		expr, err := parseExpression(query.MetricBaseQuery)
+		if err != nil {
+			return nil, err
+		}
+		shifted := query
+		shifted.MetricBaseQuery = expr.withMetrics(p.apply(query.MetricBaseQuery))
+
+		req, err := valueQueryToInput(shifted)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.GetMetricValue(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		frames, err := expr.evaluateValues(resp.GetFrames())
+		if err != nil {
+			return nil, err
+		}
+		p.restoreValues(frames)
+		res.GetMetricValueResponse.Frames = append(res.GetMetricValueResponse.Frames, frames...)
+	}
+	return res, nil
 }
@@ -5,35 +5,50 @@ import (
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/samber/lo"
 )
 
+func listMetrics(ctx context.Context, client client.BackendAPIClient, dimensions []models.Dimension, filter string) ([]*pb.ListMetricsResponse_Metric, error) {
+	resp, err := client.ListMetrics(ctx, &pb.ListMetricsRequest{
+		Dimensions: dimensionsToInput(dimensions),
+		Filter:     filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetMetrics(), nil
+}
+
+// ListMetrics returns the metrics for the dimensions of the query. If the backend does not support the operators of
+// the dimensions, it returns the metrics of all dimension values which match the operators.
 func ListMetrics(ctx context.Context, client client.BackendAPIClient, query models.GetMetricsRequest) (*models.GetMetricsResponse, error) {
 	if len(query.Dimensions) == 0 {
 		return nil, nil
 	}
-	dimensions := make([]*pb.Dimension, len(query.Dimensions))
-	for i, d := range query.Dimensions {
-		dimensions[i] = &pb.Dimension{
-			Key:   d.Key,
-			Value: d.Value,
-		}
+	metrics, err := listMetrics(ctx, client, query.Dimensions, query.Filter)
+	if operatorsNotSupported(err, query.Dimensions) {
+		metrics = nil
+		err = forEachContext(ctx, client, query.Dimensions, func(dimensions []models.Dimension) error {
+			res, err := listMetrics(ctx, client, dimensions, query.Filter)
+			metrics = append(metrics, res...)
+			return err
+		})
+		metrics = lo.UniqBy(metrics, func(m *pb.ListMetricsResponse_Metric) string { return m.Name })
 	}
-	resp, err := client.ListMetrics(ctx, &pb.ListMetricsRequest{
-		Dimensions: dimensions,
-		Filter:     query.Filter,
-	})
-
 	if err != nil {
 		return nil, err
 	}
 	return &models.GetMetricsResponse{
-		Metrics: lo.Map(resp.GetMetrics(), func(m *pb.ListMetricsResponse_Metric, _ int) models.MetricDefinition {
+		Metrics: lo.Map(metrics, func(m *pb.ListMetricsResponse_Metric, _ int) models.MetricDefinition {
 			return models.MetricDefinition{
 				Value:       m.Name,
-				Label:       m.Name,
+				Label:       labelOrID(m.Label, m.Name),
 				Description: m.Description,
+				Group:       m.Group,
+				Icon:        m.Icon,
+				Color:       m.Color,
+				Unit:        m.Unit,
 			}
 		}),
 	}, nil
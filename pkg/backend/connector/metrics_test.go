@@ -5,16 +5,19 @@ import (
 	"testing"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Returns all metrics from the system
-func (clientmock *clientMock) ListMetrics(ctx context.Context, in *v3.ListMetricsRequest, opts ...grpc.CallOption) (*v3.ListMetricsResponse, error) {
+func (clientmock *clientMock) ListMetrics(ctx context.Context, in *v5.ListMetricsRequest, opts ...grpc.CallOption) (*v5.ListMetricsResponse, error) {
 	args := clientmock.Called(ctx, in, opts)
-	if v, ok := args.Get(0).(*v3.ListMetricsResponse); ok {
+	if v, ok := args.Get(0).(*v5.ListMetricsResponse); ok {
 		return v, args.Error(1)
 	}
 	return nil, args.Error(1)
@@ -28,17 +31,25 @@ func TestListMetrics(t *testing.T) {
 			{Key: "foo", Value: "bar"},
 		},
 	}
-	m.On("ListMetrics", mock.Anything, &v3.ListMetricsRequest{
+	m.On("ListMetrics", mock.Anything, &v5.ListMetricsRequest{
 		Filter: req.Filter,
-		Dimensions: []*v3.Dimension{
+		Dimensions: []*v5.Dimension{
 			{Key: "foo", Value: "bar"},
 		},
-	}, mock.Anything).Return(&v3.ListMetricsResponse{
-		Metrics: []*v3.ListMetricsResponse_Metric{
+	}, mock.Anything).Return(&v5.ListMetricsResponse{
+		Metrics: []*v5.ListMetricsResponse_Metric{
 			{
 				Name:        "foo",
 				Description: "bar",
 			},
+			{
+				Name:        "3f2c9a7e",
+				Label:       "Temperature",
+				Description: "Motor temperature",
+				Group:       "Motor",
+				Icon:        "thermometer",
+				Color:       "red",
+			},
 		},
 	}, nil)
 	res, err := ListMetrics(context.TODO(), m, req)
@@ -47,7 +58,35 @@ func TestListMetrics(t *testing.T) {
 	exp := &models.GetMetricsResponse{
 		Metrics: []models.MetricDefinition{
 			{Value: "foo", Label: "foo", Description: "bar"},
+			{Value: "3f2c9a7e", Label: "Temperature", Description: "Motor temperature", Group: "Motor", Icon: "thermometer", Color: "red"},
 		},
 	}
 	assert.EqualValues(t, exp, res)
 }
+
+// equalityOnlyMock is a backend which does not support dimension operators; it returns a metric for each machine
+type equalityOnlyMock struct {
+	dimensionValuesMock
+}
+
+func (m *equalityOnlyMock) ListMetrics(ctx context.Context, in *v5.ListMetricsRequest, opts ...grpc.CallOption) (*v5.ListMetricsResponse, error) {
+	res := &v5.ListMetricsResponse{}
+	for _, d := range in.Dimensions {
+		if d.Operator != v5.DimensionOperator_DIMENSION_OPERATOR_EQUAL {
+			return nil, status.Error(codes.Unimplemented, "not supported")
+		}
+		if d.Key == "machine" {
+			res.Metrics = append(res.Metrics, &v5.ListMetricsResponse_Metric{Name: "temperature"}, &v5.ListMetricsResponse_Metric{Name: d.Value + "_speed"})
+		}
+	}
+	return res, nil
+}
+
+func TestListMetrics_DimensionOperators(t *testing.T) {
+	m := &equalityOnlyMock{dimensionValuesMock{values: map[string][]string{"machine": {"press-1", "press-2", "lathe-1"}}}}
+	res, err := ListMetrics(context.TODO(), m, models.GetMetricsRequest{
+		Dimensions: []models.Dimension{{Key: "machine", Value: "press-.*", Operator: models.DimensionOperatorRegex}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"temperature", "press-1_speed", "press-2_speed"}, lo.Map(res.Metrics, func(m models.MetricDefinition, _ int) string { return m.Value }))
+}
@@ -0,0 +1,171 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// page is a single page of a paginated query result
+type page interface {
+	proto.Message
+	GetFrames() []*pb.Frame
+	GetNextToken() string
+}
+
+// PartialResultError is returned if a page fails while paging through the result of a query which accepts partial results.
+// The frames which were collected before the failure are returned along with the error.
+type PartialResultError struct {
+	// Page is the (1-based) number of the page which failed
+	Page int
+	Err  error
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("page %d failed: %s", e.Page, e.Err.Error())
+}
+
+func (e *PartialResultError) Unwrap() error {
+	return e.Err
+}
+
+// itemLimit is the max. number of values per series of a metric history query, and the end of the history which is kept
+type itemLimit struct {
+	max      int64
+	ordering models.TimeOrdering
+}
+
+// paginate retrieves the pages of a query result until the last page or one of the query limits is reached.
+// If the max. number of items is specified, it stops as soon as every series of the metrics of the query has reached
+// this number.
+func paginate(ctx context.Context, query models.MetricBaseQuery, items itemLimit, getPage func(ctx context.Context, token string) (page, error)) ([]*pb.Frame, []data.Notice, error) {
+	collector := newPageCollector(query)
+
+	merger := newFrameMerger()
+	var notices []data.Notice
+	token := query.NextToken
+	for {
+		resp, err := getPage(ctx, token)
+
+		if err != nil {
+			if query.PartialResults && collector.pages > 0 {
+				st := status.Convert(err)
+				notices = append(notices, data.Notice{
+					Severity: data.NoticeSeverityError,
+					Text:     fmt.Sprintf("Page %d failed with %s: %s; the result only contains the data of the first %d pages", collector.pages+1, st.Code(), st.Message(), collector.pages),
+				})
+				return limitItems(merger.result(), items), notices, &PartialResultError{Page: collector.pages + 1, Err: err}
+			}
+			return nil, nil, err
+		}
+		if err := collector.add(resp, resp.GetFrames()); err != nil {
+			return nil, nil, err
+		}
+
+		merger.append(resp.GetFrames())
+
+		if resp.GetNextToken() == "" || merger.reached(query.Metrics, items.max) {
+			break
+		}
+		if notice, truncated := collector.truncated(); truncated {
+			notices = append(notices, notice)
+			break
+		}
+		token = resp.GetNextToken()
+	}
+	return limitItems(merger.result(), items), notices, nil
+}
+
+// limitItems restricts the values of the (ascending) frames to the max. number of items; these are the most recent
+// values for a descending time ordering and the oldest values otherwise.
+func limitItems(frames []*pb.Frame, items itemLimit) []*pb.Frame {
+	if items.max <= 0 {
+		return frames
+	}
+	for i, frame := range frames {
+		n := len(frame.Timestamps)
+		if int64(n) <= items.max {
+			continue
+		}
+		start, end := 0, int(items.max)
+		if items.ordering == models.TimeOrderingDescending {
+			start, end = n-int(items.max), n
+		}
+		frames[i] = sliceRows(frame, start, end)
+	}
+	return frames
+}
+
+// pageCollector keeps track of the amount of data which is collected while paging through a query result.
+type pageCollector struct {
+	limits     models.QueryLimits
+	hardLimits models.QueryLimits
+
+	pages  int
+	points int64
+	bytes  int64
+}
+
+func newPageCollector(query models.MetricBaseQuery) *pageCollector {
+	return &pageCollector{
+		limits:     query.Limits,
+		hardLimits: query.HardLimits,
+	}
+}
+
+// add registers a page; it returns an error if the collected data exceeds the hard limits.
+func (c *pageCollector) add(page proto.Message, frames []*pb.Frame) error {
+	c.pages++
+	c.bytes += int64(proto.Size(page))
+	for _, frame := range frames {
+		for _, fld := range frame.Fields {
+			c.points += int64(len(fld.Values) + len(fld.StringValues))
+		}
+	}
+	if reason, exceeded := c.exceeds(c.hardLimits); exceeded {
+		return status.Errorf(codes.ResourceExhausted, "query result exceeds the hard limit of %s; please select a smaller period", reason)
+	}
+	return nil
+}
+
+// exceeds returns whether the collected data has gone past one of the (hard) limits; a result which is exactly at a
+// limit is valid.
+func (c *pageCollector) exceeds(limits models.QueryLimits) (string, bool) {
+	return c.check(limits, func(n, max int64) bool { return n > max })
+}
+
+// reached returns whether the collected data has reached one of the (soft) limits, so no more pages are retrieved.
+func (c *pageCollector) reached(limits models.QueryLimits) (string, bool) {
+	return c.check(limits, func(n, max int64) bool { return n >= max })
+}
+
+func (c *pageCollector) check(limits models.QueryLimits, over func(n, max int64) bool) (string, bool) {
+	switch {
+	case limits.MaxPages > 0 && over(int64(c.pages), int64(limits.MaxPages)):
+		return fmt.Sprintf("%d pages", limits.MaxPages), true
+	case limits.MaxPoints > 0 && over(c.points, limits.MaxPoints):
+		return fmt.Sprintf("%d points", limits.MaxPoints), true
+	case limits.MaxBytes > 0 && over(c.bytes, limits.MaxBytes):
+		return fmt.Sprintf("%d bytes", limits.MaxBytes), true
+	default:
+		return "", false
+	}
+}
+
+// truncated returns a notice if the collector has reached its limits and no more pages should be retrieved.
+func (c *pageCollector) truncated() (data.Notice, bool) {
+	reason, exceeded := c.reached(c.limits)
+	if !exceeded {
+		return data.Notice{}, false
+	}
+	return data.Notice{
+		Severity: data.NoticeSeverityWarning,
+		Text:     fmt.Sprintf("The result is truncated after %d pages because it reached the limit of %s; please select a smaller period or increase the query limits", c.pages, reason),
+	}, true
+}
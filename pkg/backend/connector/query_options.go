@@ -2,24 +2,30 @@ package connector
 
 import (
 	"context"
+	"errors"
+	"strconv"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func GetQueryOptionDefinitions(ctx context.Context, client client.BackendAPIClient, input models.GetQueryOptionsRequest) (*models.GetQueryOptionsResponse, error) {
-	var qt v3.GetOptionsRequest_QueryType
+	var qt v5.GetOptionsRequest_QueryType
 	switch input.QueryType {
 	case models.QueryMetricValue:
-		qt = v3.GetOptionsRequest_GetMetricValue
+		qt = v5.GetOptionsRequest_GetMetricValue
 	case models.QueryMetricHistory:
-		qt = v3.GetOptionsRequest_GetMetricHistory
+		qt = v5.GetOptionsRequest_GetMetricHistory
 	default:
-		qt = v3.GetOptionsRequest_GetMetricAggregate
+		qt = v5.GetOptionsRequest_GetMetricAggregate
 	}
-	resp, err := client.GetQueryOptions(ctx, &v3.GetOptionsRequest{
+	resp, err := client.GetQueryOptions(ctx, &v5.GetOptionsRequest{
 		QueryType:       qt,
 		SelectedOptions: input.SelectedOptions,
 	})
@@ -28,24 +34,107 @@ func GetQueryOptionDefinitions(ctx context.Context, client client.BackendAPIClie
 		return nil, err
 	}
 
-	options := lo.Map(resp.Options, func(o *v3.Option, _ int) models.Option {
-		return models.Option{
-			ID:          o.Id,
-			Label:       o.Label,
-			Description: o.Description,
-			Type:        o.Type.String(),
-			EnumValues: lo.Map(o.EnumValues, func(v *v3.EnumValue, _ int) models.EnumValue {
-				return models.EnumValue{
-					Label:       v.Label,
-					ID:          v.Id,
-					Description: v.Description,
-					Default:     v.Default,
-				}
-			}),
-			Required: o.Required,
-		}
-	})
 	return &models.GetQueryOptionsResponse{
-		Options: options,
+		Options: lo.Map(resp.Options, func(o *v5.Option, _ int) models.Option { return optionFromOutput(o) }),
 	}, nil
 }
+
+func optionFromOutput(o *v5.Option) models.Option {
+	option := models.Option{
+		ID:          o.Id,
+		Label:       o.Label,
+		Description: o.Description,
+		Type:        o.Type.String(),
+		EnumValues: lo.Map(o.EnumValues, func(v *v5.EnumValue, _ int) models.EnumValue {
+			return models.EnumValue{
+				Label:       v.Label,
+				ID:          v.Id,
+				Description: v.Description,
+				Default:     v.Default,
+			}
+		}),
+		Required: o.Required,
+		DependsOn: lo.Map(o.DependsOn, func(d *v5.OptionDependency, _ int) models.OptionDependency {
+			return models.OptionDependency{OptionID: d.OptionId, Values: d.Values}
+		}),
+	}
+	if c := o.Constraints; c != nil {
+		option.Min, option.Max, option.Step, option.Pattern = c.Min, c.Max, c.Step, c.Pattern
+	}
+	if o.DefaultValue != nil {
+		option.DefaultValue = lo.ToPtr(optionValueFromOutput(o.DefaultValue))
+	}
+	if len(option.DependsOn) == 0 {
+		option.DependsOn = nil
+	}
+	return option
+}
+
+func optionValueFromOutput(v *v5.OptionValue) models.OptionValue {
+	switch v.GetValue().(type) {
+	case *v5.OptionValue_Number:
+		return models.OptionValue{Value: strconv.FormatFloat(v.GetNumber(), 'f', -1, 64)}
+	case *v5.OptionValue_Boolean:
+		return models.OptionValue{Value: strconv.FormatBool(v.GetBoolean())}
+	case *v5.OptionValue_Duration:
+		return models.OptionValue{Value: v.GetDuration().AsDuration().String()}
+	case *v5.OptionValue_DateRange:
+		return models.OptionValue{DateRange: &models.DateRange{From: v.GetDateRange().GetFrom().AsTime(), To: v.GetDateRange().GetTo().AsTime()}}
+	case *v5.OptionValue_Values:
+		return models.OptionValue{Values: v.GetValues().GetValues()}
+	default:
+		return models.OptionValue{Value: v.GetText()}
+	}
+}
+
+// optionsToInput converts the options of a query to the typed options of the backend; an option without a type is
+// passed as text
+func optionsToInput(options map[string]models.OptionValue) (map[string]*v5.OptionValue, error) {
+	if len(options) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]*v5.OptionValue, len(options))
+	for k, v := range options {
+		value, err := optionValueToInput(v)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid value of option %s: %s", k, err)
+		}
+		res[k] = value
+	}
+	return res, nil
+}
+
+func optionValueToInput(v models.OptionValue) (*v5.OptionValue, error) {
+	switch v.Type {
+	case models.OptionTypeNumber:
+		n, err := strconv.ParseFloat(v.Value, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &v5.OptionValue{Value: &v5.OptionValue_Number{Number: n}}, nil
+	case models.OptionTypeBoolean:
+		b, err := strconv.ParseBool(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &v5.OptionValue{Value: &v5.OptionValue_Boolean{Boolean: b}}, nil
+	case models.OptionTypeDuration:
+		d, err := models.ParseDuration(v.Value)
+		if err != nil {
+			return nil, err
+		}
+		return &v5.OptionValue{Value: &v5.OptionValue_Duration{Duration: durationpb.New(d)}}, nil
+	case models.OptionTypeDateRange:
+		if v.DateRange == nil {
+			return nil, errors.New("no date range")
+		}
+		return &v5.OptionValue{Value: &v5.OptionValue_DateRange{DateRange: &v5.DateRange{
+			From: timestamppb.New(v.DateRange.From),
+			To:   timestamppb.New(v.DateRange.To),
+		}}}, nil
+	case models.OptionTypeMultiEnum:
+		return &v5.OptionValue{Value: &v5.OptionValue_Values{Values: &v5.StringList{Values: v.SelectedValues()}}}, nil
+	default:
+		return &v5.OptionValue{Value: &v5.OptionValue_Text{Text: v.Value}}, nil
+	}
+}
@@ -3,22 +3,27 @@ package connector
 import (
 	"context"
 	"testing"
+	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	v3 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Gets the options for the specified query type
 func (clientmock *clientMock) GetQueryOptions(
 	ctx context.Context,
-	in *v3.GetOptionsRequest,
+	in *v5.GetOptionsRequest,
 	opts ...grpc.CallOption,
-) (*v3.GetOptionsResponse, error) {
+) (*v5.GetOptionsResponse, error) {
 	args := clientmock.Called(ctx, in, opts)
-	if v, ok := args.Get(0).(*v3.GetOptionsResponse); ok {
+	if v, ok := args.Get(0).(*v5.GetOptionsResponse); ok {
 		return v, args.Error(1)
 	}
 	return nil, args.Error(1)
@@ -32,20 +37,20 @@ func TestGetQueryOptions(t *testing.T) {
 			"foo": "bar",
 		},
 	}
-	m.On("GetQueryOptions", mock.Anything, &v3.GetOptionsRequest{
-		QueryType: v3.GetOptionsRequest_GetMetricAggregate,
+	m.On("GetQueryOptions", mock.Anything, &v5.GetOptionsRequest{
+		QueryType: v5.GetOptionsRequest_GetMetricAggregate,
 		SelectedOptions: map[string]string{
 			"foo": "bar",
 		},
-	}, mock.Anything).Return(&v3.GetOptionsResponse{
-		Options: []*v3.Option{
+	}, mock.Anything).Return(&v5.GetOptionsResponse{
+		Options: []*v5.Option{
 			{
 				Id:          "foo",
-				Type:        v3.Option_Enum,
+				Type:        v5.Option_Enum,
 				Description: "the foo option",
 				Required:    true,
 				Label:       "foo label",
-				EnumValues: []*v3.EnumValue{
+				EnumValues: []*v5.EnumValue{
 					{
 						Id:          "bar",
 						Label:       "bar label",
@@ -80,3 +85,67 @@ func TestGetQueryOptions(t *testing.T) {
 	}
 	assert.EqualValues(t, exp, res)
 }
+
+func TestGetQueryOptions_Types(t *testing.T) {
+	m := &clientMock{}
+	m.On("GetQueryOptions", mock.Anything, mock.Anything, mock.Anything).Return(&v5.GetOptionsResponse{
+		Options: []*v5.Option{
+			{
+				Id:           "percentile",
+				Type:         v5.Option_Number,
+				Constraints:  &v5.OptionConstraints{Min: lo.ToPtr(0.0), Max: lo.ToPtr(100.0), Step: 0.5},
+				DefaultValue: &v5.OptionValue{Value: &v5.OptionValue_Number{Number: 95}},
+				DependsOn:    []*v5.OptionDependency{{OptionId: "aggregate", Values: []string{"percentile"}}},
+			},
+			{
+				Id:           "window",
+				Type:         v5.Option_Duration,
+				DefaultValue: &v5.OptionValue{Value: &v5.OptionValue_Duration{Duration: durationpb.New(5 * time.Minute)}},
+			},
+		},
+	}, nil)
+	res, err := GetQueryOptionDefinitions(context.TODO(), m, models.GetQueryOptionsRequest{QueryType: models.QueryMetricAggregate})
+	assert.NoError(t, err)
+	assert.Equal(t, models.Options{
+		{
+			ID:           "percentile",
+			Type:         models.OptionTypeNumber,
+			EnumValues:   []models.EnumValue{},
+			Min:          lo.ToPtr(0.0),
+			Max:          lo.ToPtr(100.0),
+			Step:         0.5,
+			DefaultValue: &models.OptionValue{Value: "95"},
+			DependsOn:    []models.OptionDependency{{OptionID: "aggregate", Values: []string{"percentile"}}},
+		},
+		{
+			ID:           "window",
+			Type:         models.OptionTypeDuration,
+			EnumValues:   []models.EnumValue{},
+			DefaultValue: &models.OptionValue{Value: "5m0s"},
+		},
+	}, res.Options)
+}
+
+func Test_optionsToInput(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	res, err := optionsToInput(map[string]models.OptionValue{
+		"aggregate":   {Value: "avg", Type: models.OptionTypeEnum},
+		"percentile":  {Value: "99.5", Type: models.OptionTypeNumber},
+		"interpolate": {Value: "true", Type: models.OptionTypeBoolean},
+		"window":      {Value: "1h30m", Type: models.OptionTypeDuration},
+		"period":      {DateRange: &models.DateRange{From: from, To: from.Add(time.Hour)}, Type: models.OptionTypeDateRange},
+		"shifts":      {Values: []string{"a", "b"}, Type: models.OptionTypeMultiEnum},
+		"untyped":     {Value: "foo"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "avg", res["aggregate"].GetText())
+	assert.Equal(t, 99.5, res["percentile"].GetNumber())
+	assert.True(t, res["interpolate"].GetBoolean())
+	assert.Equal(t, 90*time.Minute, res["window"].GetDuration().AsDuration())
+	assert.Equal(t, from, res["period"].GetDateRange().GetFrom().AsTime())
+	assert.Equal(t, []string{"a", "b"}, res["shifts"].GetValues().GetValues())
+	assert.Equal(t, "foo", res["untyped"].GetText())
+
+	_, err = optionsToInput(map[string]models.OptionValue{"percentile": {Value: "high", Type: models.OptionTypeNumber}})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
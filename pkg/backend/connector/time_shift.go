@@ -0,0 +1,91 @@
+package connector
+
+import (
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+)
+
+// period is a time shifted variant of a query
+type period struct {
+	shift models.Shift
+	// loc is the time zone in which days and weeks are shifted
+	loc *time.Location
+	// label is the value of the period label of the series; no label is added if it is empty
+	label string
+}
+
+// queryPeriods returns the periods which are retrieved for a query: the (shifted) query itself and optionally the
+// comparison period. Only the series of a comparison are labeled with their period.
+func queryPeriods(query models.MetricBaseQuery) ([]period, error) {
+	shift, err := models.ParseShift(query.TimeShift)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "time shift: %s", err)
+	}
+	loc, err := time.LoadLocation(query.TimeZone)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid time zone %q", query.TimeZone)
+	}
+	if query.CompareOffset == "" {
+		return []period{{shift: shift, loc: loc}}, nil
+	}
+	offset, err := models.ParseShift(query.CompareOffset)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "compare offset: %s", err)
+	}
+	return []period{
+		{shift: shift, loc: loc, label: models.PeriodCurrent},
+		{shift: shift.Plus(offset), loc: loc, label: models.PeriodPrevious},
+	}, nil
+}
+
+// apply returns the query with the time range of the period
+func (p period) apply(query models.MetricBaseQuery) models.MetricBaseQuery {
+	if p.shift.IsZero() {
+		return query
+	}
+	query.TimeRange.From = p.shift.Apply(query.TimeRange.From, p.loc)
+	query.TimeRange.To = p.shift.Apply(query.TimeRange.To, p.loc)
+	return query
+}
+
+// restore moves the timestamps of the frames back onto the time range of the original query and labels the fields with the period
+func (p period) restore(frames []*pb.Frame) {
+	for _, frame := range frames {
+		for i, ts := range frame.Timestamps {
+			frame.Timestamps[i] = p.restoreTimestamp(ts)
+		}
+		for _, fld := range frame.Fields {
+			fld.Labels = p.addLabel(fld.Labels)
+		}
+	}
+}
+
+// restoreValues is the equivalent of restore for the frames of a metric value response
+func (p period) restoreValues(frames []*pb.GetMetricValueResponse_Frame) {
+	for _, frame := range frames {
+		frame.Timestamp = p.restoreTimestamp(frame.Timestamp)
+		for _, fld := range frame.Fields {
+			fld.Labels = p.addLabel(fld.Labels)
+		}
+	}
+}
+
+func (p period) restoreTimestamp(ts *timestamppb.Timestamp) *timestamppb.Timestamp {
+	if p.shift.IsZero() || ts == nil {
+		return ts
+	}
+	return timestamppb.New(p.shift.Revert(ts.AsTime(), p.loc))
+}
+
+func (p period) addLabel(labels []*pb.Label) []*pb.Label {
+	if p.label == "" {
+		return labels
+	}
+	return append(append([]*pb.Label{}, labels...), &pb.Label{Key: models.PeriodLabel, Value: p.label})
+}
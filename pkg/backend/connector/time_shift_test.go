@@ -0,0 +1,137 @@
+package connector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	v5 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Gets the values for one or more metrics; the value is the start date of the request (in unix seconds)
+func (clientmock *clientMock) GetMetricValue(ctx context.Context, in *v5.GetMetricValueRequest, opts ...grpc.CallOption) (*v5.GetMetricValueResponse, error) {
+	clientmock.Called(ctx, in.StartDate.AsTime())
+	return &v5.GetMetricValueResponse{
+		Frames: []*v5.GetMetricValueResponse_Frame{
+			{
+				Metric:    "temperature",
+				Timestamp: in.StartDate,
+				Fields:    []*v5.SingleValueField{{Name: "value", Value: float64(in.StartDate.AsTime().Unix())}},
+			},
+		},
+	}, nil
+}
+
+func labelValue(labels []*v5.Label, key string) string {
+	for _, l := range labels {
+		if l.Key == key {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+func TestGetMetricValue_TimeShift(t *testing.T) {
+	now := time.Unix(1_000_000, 0)
+	week := 7 * 24 * time.Hour
+	timeRange := backend.TimeRange{From: now.Add(-time.Hour), To: now}
+
+	t.Run("should query the shifted time range", func(t *testing.T) {
+		m := &clientMock{}
+		m.On("GetMetricValue", mock.Anything, timeRange.From.Add(-week).UTC()).Return()
+
+		query := models.MetricValueQuery{MetricBaseQuery: models.MetricBaseQuery{TimeRange: timeRange, TimeShift: "-7d"}}
+		res, err := GetMetricValue(context.TODO(), m, query)
+		assert.NoError(t, err)
+		m.AssertExpectations(t)
+
+		frames := res.GetFrames()
+		if assert.Len(t, frames, 1) {
+			assert.Equal(t, timeRange.From.Unix(), frames[0].Timestamp.AsTime().Unix())
+			assert.Equal(t, float64(timeRange.From.Add(-week).Unix()), frames[0].Fields[0].Value)
+			assert.Empty(t, labelValue(frames[0].Fields[0].Labels, models.PeriodLabel), "a time shift without comparison is not labeled")
+		}
+	})
+
+	t.Run("should shift days in the time zone of the query", func(t *testing.T) {
+		ams, _ := time.LoadLocation("Europe/Amsterdam")
+		// the week before the start of daylight saving time is one hour longer
+		from := time.Date(2023, 3, 27, 12, 0, 0, 0, ams)
+		m := &clientMock{}
+		m.On("GetMetricValue", mock.Anything, time.Date(2023, 3, 20, 12, 0, 0, 0, ams).UTC()).Return()
+
+		query := models.MetricValueQuery{MetricBaseQuery: models.MetricBaseQuery{
+			TimeRange: backend.TimeRange{From: from, To: from.Add(time.Hour)},
+			TimeShift: "-1w",
+			TimeZone:  "Europe/Amsterdam",
+		}}
+		res, err := GetMetricValue(context.TODO(), m, query)
+		assert.NoError(t, err)
+		m.AssertExpectations(t)
+		if frames := res.GetFrames(); assert.Len(t, frames, 1) {
+			assert.Equal(t, from.Unix(), frames[0].Timestamp.AsTime().Unix(), "the points line up with the current period")
+		}
+	})
+
+	t.Run("should add the comparison period", func(t *testing.T) {
+		m := &clientMock{}
+		m.On("GetMetricValue", mock.Anything, timeRange.From.UTC()).Return()
+		m.On("GetMetricValue", mock.Anything, timeRange.From.Add(-week).UTC()).Return()
+
+		query := models.MetricValueQuery{MetricBaseQuery: models.MetricBaseQuery{TimeRange: timeRange, CompareOffset: "-1w"}}
+		res, err := GetMetricValue(context.TODO(), m, query)
+		assert.NoError(t, err)
+		m.AssertExpectations(t)
+
+		frames := res.GetFrames()
+		if assert.Len(t, frames, 2) {
+			assert.Equal(t, models.PeriodCurrent, labelValue(frames[0].Fields[0].Labels, models.PeriodLabel))
+			assert.Equal(t, models.PeriodPrevious, labelValue(frames[1].Fields[0].Labels, models.PeriodLabel))
+			assert.Equal(t, frames[0].Timestamp.AsTime(), frames[1].Timestamp.AsTime())
+		}
+	})
+
+	t.Run("should reject an invalid time shift", func(t *testing.T) {
+		query := models.MetricValueQuery{MetricBaseQuery: models.MetricBaseQuery{TimeShift: "last week"}}
+		_, err := GetMetricValue(context.TODO(), &clientMock{}, query)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestGetMetricHistory_CompareOffset(t *testing.T) {
+	m := &clientMock{}
+	m.On("GetMetricHistory", mock.Anything, "").Return(&v5.GetMetricHistoryResponse{
+		Frames: []*v5.Frame{
+			{
+				Metric:     "temperature",
+				Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Unix(100_000, 0))},
+				Fields:     []*v5.Field{{Name: "value", Labels: []*v5.Label{{Key: "zone", Value: "a"}}, Values: []float64{1}}},
+			},
+		},
+	}, nil)
+
+	query := models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{CompareOffset: "-1d", DisplayName: "{{zone}} {{period}}"}}
+	res, err := GetMetricHistory(context.TODO(), m, query)
+	assert.NoError(t, err)
+
+	frames := res.GetFrames()
+	if assert.Len(t, frames, 2) {
+		assert.Equal(t, []int64{100_000}, seconds(frames[0].Timestamps))
+		assert.Equal(t, []int64{100_000 + 24*60*60}, seconds(frames[1].Timestamps))
+
+		current, err := res.FormatDisplayName(frames[0], frames[0].Fields[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "a current", current)
+		previous, err := res.FormatDisplayName(frames[1], frames[1].Fields[0])
+		assert.NoError(t, err)
+		assert.Equal(t, "a previous", previous)
+	}
+}
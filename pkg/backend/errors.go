@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/connector"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/pkg/errors"
@@ -14,12 +15,33 @@ func backendErrorResponse(err error) (data.Frames, error) {
 	return nil, convertBackendError(st)
 }
 
+// backendError is a plugin error message which retains the grpc status of the original error
+type backendError struct {
+	error
+	st *status.Status
+}
+
+func (e backendError) GRPCStatus() *status.Status {
+	return e.st
+}
+
 // convertBackendError converts a grpc status code to a plugin error message
 func convertBackendError(st *status.Status) error {
 	switch st.Code() {
 	case codes.DeadlineExceeded:
-		return errors.Errorf("%s: Query did not complete within the expected timeframe; please check your query configuration or try to select a smaller period", st.Code())
+		return backendError{error: errors.Errorf("%s: Query did not complete within the expected timeframe; please check your query configuration or try to select a smaller period", st.Code()), st: st}
 	default:
-		return errors.Errorf("%s: %s", st.Code(), st.Message())
+		return backendError{error: errors.Errorf("%s: %s", st.Code(), st.Message()), st: st}
+	}
+}
+
+// partialResponse returns the frames which were collected before a page failed along with the error of that page
+func partialResponse(framesFn func() (data.Frames, error), partial *connector.PartialResultError) (data.Frames, error) {
+	frames, err := framesFn()
+	if err != nil {
+		return nil, err
 	}
+	st := status.Convert(partial.Err)
+	backend.Logger.Error(st.Code().String(), "error", partial.Err, "page", partial.Page)
+	return frames, convertBackendError(st)
 }
@@ -0,0 +1,103 @@
+package backend
+
+import (
+	"context"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/connector"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+const (
+	// defaultMaxFanOut is the default max. number of series of a query with multi-valued dimensions
+	defaultMaxFanOut = 100
+	// fanOutConcurrency is the max. number of expanded queries which are executed concurrently
+	fanOutConcurrency = 8
+)
+
+// queryFunc executes a query with single-valued dimensions
+type queryFunc func(ctx context.Context, query models.MetricBaseQuery) (data.Frames, error)
+
+// expandFunc expands a query to a query for each combination of the values of its dimensions
+type expandFunc func(ctx context.Context, client client.BackendAPIClient, query models.MetricBaseQuery, maxQueries int) ([]connector.ExpandedQuery, error)
+
+// fanOut executes a query for each combination of the values of its multi-valued dimensions. The expanded queries are
+// executed concurrently; the fields of their frames are labelled with the expanded dimensions. If an expanded query
+// returns a partial result, the frames of all queries are returned along with the error of that query.
+// If the backend does not support the dimension operators of the query, the dimensions with an operator are expanded
+// to the values which match the operator as well.
+func (ds *backendImpl) fanOut(ctx context.Context, query models.MetricBaseQuery, run queryFunc) (data.Frames, error) {
+	frames, err := ds.expand(ctx, query, run, models.Dimension.IsMultiValued, connector.ExpandDimensions)
+	if status.Code(err) == codes.Unimplemented && lo.SomeBy(query.Dimensions, models.Dimension.HasOperator) {
+		return ds.expand(ctx, query, run, connector.IsExpandedByOperators, connector.ExpandOperators)
+	}
+	return frames, err
+}
+
+func (ds *backendImpl) expand(ctx context.Context, query models.MetricBaseQuery, run queryFunc, expanded func(models.Dimension) bool, expand expandFunc) (data.Frames, error) {
+	if !lo.SomeBy(query.Dimensions, expanded) {
+		return run(ctx, query)
+	}
+	maxFanOut := ds.settings.MaxFanOut
+	if maxFanOut <= 0 {
+		maxFanOut = defaultMaxFanOut
+	}
+	queries, err := expand(ctx, ds.client, query, maxFanOut)
+	if err != nil {
+		return backendErrorResponse(err)
+	}
+
+	results := make([]data.Frames, len(queries))
+	errs := make([]error, len(queries))
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(fanOutConcurrency)
+	for i := range queries {
+		i := i
+		g.Go(func() error {
+			frames, err := run(ctx, queries[i].Query)
+			if err != nil && frames == nil {
+				return err
+			}
+			for _, frame := range frames {
+				labelFrame(frame, queries[i].Labels)
+			}
+			results[i], errs[i] = frames, err
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	var res data.Frames
+	for _, frames := range results {
+		res = append(res, frames...)
+	}
+	for _, err := range errs {
+		if err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
+// labelFrame adds the labels to all fields of a frame, except the time field
+func labelFrame(frame *data.Frame, labels map[string]string) {
+	for _, fld := range frame.Fields {
+		if fld.Type() == data.FieldTypeTime {
+			continue
+		}
+		if fld.Labels == nil {
+			fld.Labels = data.Labels{}
+		}
+		for k, v := range labels {
+			fld.Labels[k] = v
+		}
+	}
+}
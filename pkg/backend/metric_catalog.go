@@ -0,0 +1,200 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// defaultMetricCatalogTTL is the default period after which the metric catalog of a dimension set is refreshed
+const defaultMetricCatalogTTL = 5 * time.Minute
+
+// metricCatalogFailureTTL is the period after which a failed refresh of the metric catalog is retried; a backend
+// without a metric catalog (Unimplemented) is not called for every query
+const metricCatalogFailureTTL = 30 * time.Second
+
+// metricCatalogRefreshTimeout limits the duration of a refresh of the metric catalog, which runs in the background
+const metricCatalogRefreshTimeout = 30 * time.Second
+
+// metricCatalogWaitTimeout limits how long a query waits for the metrics of a dimension set which is not yet known
+const metricCatalogWaitTimeout = 2 * time.Second
+
+// MetricsRegistry is the registry of the metrics of the plugin. The metrics are registered once, however many
+// datasources the plugin serves; main adds it to the metrics which are gathered by Grafana.
+var MetricsRegistry = prometheus.NewRegistry()
+
+var (
+	catalogLookups = promauto.With(MetricsRegistry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana_plugin",
+		Subsystem: "metric_catalog",
+		Name:      "lookups_total",
+		Help:      "The number of metric catalog lookups by datasource and result (hit, miss or stale)",
+	}, []string{"datasource", "result"})
+	catalogRefreshErrors = promauto.With(MetricsRegistry).NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana_plugin",
+		Subsystem: "metric_catalog",
+		Name:      "refresh_errors_total",
+		Help:      "The number of metric catalog refreshes which failed",
+	}, []string{"datasource"})
+)
+
+// listMetricsFunc lists the metrics of a dimension set
+type listMetricsFunc func(ctx context.Context, query models.GetMetricsRequest) (*models.GetMetricsResponse, error)
+
+type catalogEntry struct {
+	metrics map[string]models.MetricDefinition
+	expires time.Time
+}
+
+// metricCatalog caches the metrics of a dimension set by metric id. The first lookup of a dimension set waits a short
+// while for its metrics to be listed; the metrics of an expired dimension set are listed in the background, while the
+// lookup returns the expired metrics. A failed refresh is cached without metrics, and retried after a short period.
+// Entries which are not refreshed within a TTL after they expire are evicted.
+type metricCatalog struct {
+	mu      sync.Mutex
+	entries map[string]catalogEntry
+	// refreshing contains a channel for each dimension set which is being refreshed; it is closed when the refresh is done
+	refreshing map[string]chan struct{}
+	refreshes  sync.WaitGroup
+	// ctx is the context of the refreshes, which is canceled when the catalog is disposed
+	ctx        context.Context
+	cancel     context.CancelFunc
+	list       listMetricsFunc
+	ttl        time.Duration
+	datasource string
+	now        func() time.Time
+}
+
+func newMetricCatalog(datasource string, ttl time.Duration, list listMetricsFunc) *metricCatalog {
+	if ttl <= 0 {
+		ttl = defaultMetricCatalogTTL
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &metricCatalog{
+		entries:    map[string]catalogEntry{},
+		refreshing: map[string]chan struct{}{},
+		ctx:        ctx,
+		cancel:     cancel,
+		list:       list,
+		ttl:        ttl,
+		datasource: datasource,
+		now:        time.Now,
+	}
+}
+
+// dispose cancels the running refreshes and waits until they are done
+func (c *metricCatalog) dispose() {
+	c.cancel()
+	c.refreshes.Wait()
+}
+
+// catalogDimensions returns the dimensions of a query which select a single value; the metrics of a wildcard or a
+// list of values are not listed per value
+func catalogDimensions(dimensions []models.Dimension) []models.Dimension {
+	return lo.Reject(dimensions, func(d models.Dimension, _ int) bool { return d.IsMultiValued() })
+}
+
+// get returns the metrics for the dimensions by metric id; it returns nil if the metrics are not (yet) known
+func (c *metricCatalog) get(ctx context.Context, dimensions []models.Dimension) map[string]models.MetricDefinition {
+	dimensions = catalogDimensions(dimensions)
+	b, err := json.Marshal(dimensions)
+	if err != nil {
+		return nil
+	}
+	key := string(b)
+
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok && now.Before(entry.expires) {
+		c.mu.Unlock()
+		catalogLookups.WithLabelValues(c.datasource, "hit").Inc()
+		return entry.metrics
+	}
+	done := c.startRefresh(key, dimensions)
+	c.mu.Unlock()
+
+	if ok {
+		// the expired metrics are used while the catalog is refreshed in the background
+		catalogLookups.WithLabelValues(c.datasource, "stale").Inc()
+		return entry.metrics
+	}
+	catalogLookups.WithLabelValues(c.datasource, "miss").Inc()
+	timer := time.NewTimer(metricCatalogWaitTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries[key].metrics
+}
+
+// startRefresh starts a refresh of a dimension set unless it is already being refreshed; it returns a channel which is
+// closed when the refresh is done. The caller holds the lock.
+func (c *metricCatalog) startRefresh(key string, dimensions []models.Dimension) chan struct{} {
+	if done, ok := c.refreshing[key]; ok {
+		return done
+	}
+	done := make(chan struct{})
+	if c.ctx.Err() != nil {
+		// the catalog is disposed
+		close(done)
+		return done
+	}
+	c.refreshing[key] = done
+	c.refreshes.Add(1)
+	// the refresh outlives the query which triggers it
+	go c.refresh(key, dimensions, done)
+	return done
+}
+
+// refresh lists the metrics of a dimension set and stores them in the catalog
+func (c *metricCatalog) refresh(key string, dimensions []models.Dimension, done chan struct{}) {
+	defer c.refreshes.Done()
+	ctx, cancel := context.WithTimeout(c.ctx, metricCatalogRefreshTimeout)
+	defer cancel()
+	res, err := c.list(ctx, models.GetMetricsRequest{Dimensions: dimensions})
+
+	now := c.now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.refreshing, key)
+	defer close(done)
+	if c.ctx.Err() != nil {
+		return
+	}
+	for k, e := range c.entries {
+		if k != key && !now.Before(e.expires.Add(c.ttl)) {
+			delete(c.entries, k)
+		}
+	}
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			catalogRefreshErrors.WithLabelValues(c.datasource).Inc()
+			log.DefaultLogger.Warn("could not refresh the metric catalog", "error", err)
+		}
+		// the failure is cached, so the backend is not called for every query until the refresh is retried
+		c.entries[key] = catalogEntry{expires: now.Add(metricCatalogFailureTTL)}
+		return
+	}
+	var metrics map[string]models.MetricDefinition
+	if res != nil {
+		metrics = lo.SliceToMap(res.Metrics, func(m models.MetricDefinition) (string, models.MetricDefinition) { return m.Value, m })
+	}
+	c.entries[key] = catalogEntry{metrics: metrics, expires: now.Add(c.ttl)}
+}
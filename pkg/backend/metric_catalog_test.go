@@ -0,0 +1,99 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricCatalog(t *testing.T) {
+	catalogLookups.DeletePartialMatch(prometheus.Labels{"datasource": "catalog-test"})
+	catalogRefreshErrors.DeleteLabelValues("catalog-test")
+	var (
+		mu      sync.Mutex
+		calls   []models.GetMetricsRequest
+		listErr error
+	)
+	catalog := newMetricCatalog("catalog-test", time.Minute, func(ctx context.Context, query models.GetMetricsRequest) (*models.GetMetricsResponse, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, query)
+		if listErr != nil {
+			return nil, listErr
+		}
+		return &models.GetMetricsResponse{Metrics: []models.MetricDefinition{{Value: "temperature", Unit: "celsius"}}}, nil
+	})
+	now := time.Now()
+	catalog.now = func() time.Time { return now }
+	dimensions := []models.Dimension{{Key: "machine", Value: "m1"}, {Key: "line", Value: "*"}}
+	want := map[string]models.MetricDefinition{"temperature": {Value: "temperature", Unit: "celsius"}}
+
+	assert.Equal(t, want, catalog.get(context.Background(), dimensions), "the first lookup waits for the metrics to be listed")
+	assert.Equal(t, want, catalog.get(context.Background(), dimensions))
+	assert.Equal(t, []models.GetMetricsRequest{{Dimensions: []models.Dimension{{Key: "machine", Value: "m1"}}}}, calls, "the metrics are listed once for the single valued dimensions")
+
+	now = now.Add(2 * time.Minute)
+	listErr = errors.New("unavailable")
+	assert.Equal(t, want, catalog.get(context.Background(), dimensions), "the expired metrics are used while the catalog is refreshed")
+	catalog.refreshes.Wait()
+	assert.Nil(t, catalog.get(context.Background(), dimensions), "the expired metrics are not used after the refresh fails")
+	assert.Len(t, calls, 2, "a failed refresh is not retried immediately")
+
+	assert.Nil(t, catalog.get(context.Background(), []models.Dimension{{Key: "machine", Value: "m2"}}))
+	assert.Nil(t, catalog.get(context.Background(), []models.Dimension{{Key: "machine", Value: "m2"}}))
+	assert.Len(t, calls, 3, "a failure is cached")
+
+	assert.Equal(t, 3.0, testutil.ToFloat64(catalogLookups.WithLabelValues("catalog-test", "hit")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(catalogLookups.WithLabelValues("catalog-test", "miss")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(catalogLookups.WithLabelValues("catalog-test", "stale")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(catalogRefreshErrors.WithLabelValues("catalog-test")))
+}
+
+func TestMetricCatalog_Dispose(t *testing.T) {
+	started := make(chan struct{})
+	catalog := newMetricCatalog("dispose-test", time.Minute, func(ctx context.Context, query models.GetMetricsRequest) (*models.GetMetricsResponse, error) {
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	lookup := make(chan map[string]models.MetricDefinition)
+	go func() { lookup <- catalog.get(context.Background(), nil) }()
+	<-started
+	catalog.dispose()
+	assert.Nil(t, <-lookup, "a lookup does not wait for a refresh which is canceled")
+	assert.Empty(t, catalog.refreshing, "the refreshes are done when the catalog is disposed")
+	assert.Nil(t, catalog.get(context.Background(), nil), "a disposed catalog is not refreshed")
+}
+
+func TestMetricCatalog_Eviction(t *testing.T) {
+	catalogRefreshErrors.DeleteLabelValues("eviction-test")
+	listErr := status.Error(codes.Unimplemented, "not implemented")
+	catalog := newMetricCatalog("eviction-test", time.Minute, func(ctx context.Context, query models.GetMetricsRequest) (*models.GetMetricsResponse, error) {
+		if listErr != nil {
+			return nil, listErr
+		}
+		return &models.GetMetricsResponse{}, nil
+	})
+	now := time.Now()
+	catalog.now = func() time.Time { return now }
+
+	catalog.get(context.Background(), []models.Dimension{{Key: "machine", Value: "m1"}})
+	catalog.refreshes.Wait()
+	assert.Zero(t, testutil.ToFloat64(catalogRefreshErrors.WithLabelValues("eviction-test")), "a backend without a metric catalog is not an error")
+
+	now = now.Add(5 * time.Minute)
+	listErr = nil
+	catalog.get(context.Background(), []models.Dimension{{Key: "machine", Value: "m2"}})
+	catalog.refreshes.Wait()
+	assert.Len(t, catalog.entries, 1, "the entries which are expired for longer than the TTL are evicted")
+}
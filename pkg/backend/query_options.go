@@ -0,0 +1,64 @@
+package backend
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/client"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend/connector"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// optionDefinitionsTTL is the period the option definitions of a query type are cached
+const optionDefinitionsTTL = time.Minute
+
+type cachedOptions struct {
+	res     *models.GetQueryOptionsResponse
+	expires time.Time
+}
+
+// optionsCache caches the option definitions by query type and selected options. Every query which is executed
+// resolves its option definitions, so caching them saves a backend call per query.
+type optionsCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedOptions
+	now     func() time.Time
+}
+
+func newOptionsCache() *optionsCache {
+	return &optionsCache{
+		entries: map[string]cachedOptions{},
+		now:     time.Now,
+	}
+}
+
+func (c *optionsCache) get(ctx context.Context, client client.BackendAPIClient, input models.GetQueryOptionsRequest) (*models.GetQueryOptionsResponse, error) {
+	// the keys of a marshalled map are sorted, which makes it a stable cache key
+	key, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[string(key)]
+	c.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.res, nil
+	}
+
+	res, err := connector.GetQueryOptionDefinitions(ctx, client, input)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[string(key)] = cachedOptions{res: res, expires: now.Add(optionDefinitionsTTL)}
+	return res, nil
+}
@@ -0,0 +1,110 @@
+// Package expression implements arithmetic expressions which reference metrics by id, e.g. good_parts / total_parts * 100.
+//
+// An expression supports the operators +, -, * and /, unary minus, parentheses and numbers. A metric id which contains
+// other characters than letters, digits, '_', '.' and ':' is written between double quotes, e.g. "power-in" - "power-out".
+package expression
+
+import (
+	"fmt"
+	"math"
+)
+
+// SyntaxError is the error of an expression which cannot be parsed
+type SyntaxError struct {
+	// Pos is the (1-based) position of the error in the expression
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Expression is a parsed expression
+type Expression struct {
+	text    string
+	root    node
+	metrics []string
+}
+
+// Parse parses an expression; it returns a *SyntaxError if the expression is invalid.
+func Parse(text string) (*Expression, error) {
+	p := &parser{lexer: newLexer(text)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	root, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("unexpected %s", p.tok)}
+	}
+	return &Expression{text: text, root: root, metrics: p.metrics}, nil
+}
+
+// String returns the text of the expression
+func (e *Expression) String() string {
+	return e.text
+}
+
+// Metrics returns the ids of the metrics which are referenced by the expression, in order of their first appearance
+func (e *Expression) Metrics() []string {
+	return e.metrics
+}
+
+// Evaluate evaluates the expression with the values of the metrics. The result is NaN if a metric has no value
+// (NaN) or for a division by zero.
+func (e *Expression) Evaluate(value func(metric string) float64) float64 {
+	res := e.root.eval(value)
+	if math.IsInf(res, 0) {
+		return math.NaN()
+	}
+	return res
+}
+
+type node interface {
+	eval(value func(metric string) float64) float64
+}
+
+type number float64
+
+func (n number) eval(func(string) float64) float64 {
+	return float64(n)
+}
+
+type metric string
+
+func (m metric) eval(value func(string) float64) float64 {
+	return value(string(m))
+}
+
+type negate struct {
+	operand node
+}
+
+func (n negate) eval(value func(string) float64) float64 {
+	return -n.operand.eval(value)
+}
+
+type binary struct {
+	op          byte
+	left, right node
+}
+
+func (b binary) eval(value func(string) float64) float64 {
+	l, r := b.left.eval(value), b.right.eval(value)
+	switch b.op {
+	case '+':
+		return l + r
+	case '-':
+		return l - r
+	case '*':
+		return l * r
+	default:
+		if r == 0 {
+			return math.NaN()
+		}
+		return l / r
+	}
+}
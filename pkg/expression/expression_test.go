@@ -0,0 +1,80 @@
+package expression
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	values := map[string]float64{
+		"good_parts":  45,
+		"total_parts": 50,
+		"power-in":    10,
+		"power.out":   4,
+		"offline":     math.NaN(),
+	}
+	value := func(metric string) float64 {
+		if v, ok := values[metric]; ok {
+			return v
+		}
+		return math.NaN()
+	}
+
+	tests := []struct {
+		expr    string
+		want    float64
+		metrics []string
+	}{
+		{expr: "good_parts / total_parts * 100", want: 90, metrics: []string{"good_parts", "total_parts"}},
+		{expr: `"power-in" - power.out`, want: 6, metrics: []string{"power-in", "power.out"}},
+		{expr: "1 + 2 * 3", want: 7},
+		{expr: "(1 + 2) * 3", want: 9},
+		{expr: "10 - 4 - 3", want: 3},
+		{expr: "-total_parts + --5", want: -45, metrics: []string{"total_parts"}},
+		{expr: "total_parts + total_parts", want: 100, metrics: []string{"total_parts"}},
+		{expr: "good_parts / 0", want: math.NaN(), metrics: []string{"good_parts"}},
+		{expr: "good_parts + offline", want: math.NaN(), metrics: []string{"good_parts", "offline"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr)
+			if !assert.NoError(t, err) {
+				return
+			}
+			got := expr.Evaluate(value)
+			if math.IsNaN(tt.want) {
+				assert.True(t, math.IsNaN(got), "expected NaN, got %v", got)
+			} else {
+				assert.Equal(t, tt.want, got)
+			}
+			assert.Equal(t, tt.metrics, expr.Metrics())
+		})
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		expr string
+		pos  int
+	}{
+		{expr: "good_parts / ", pos: 14},
+		{expr: "(good_parts + 1", pos: 16},
+		{expr: "good_parts total_parts", pos: 12},
+		{expr: "good_parts % 2", pos: 12},
+		{expr: `"power-in - 1`, pos: 1},
+		{expr: "1.2.3", pos: 1},
+		{expr: "", pos: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr)
+			var syntaxErr *SyntaxError
+			if assert.True(t, errors.As(err, &syntaxErr), "expected a syntax error, got %v", err) {
+				assert.Equal(t, tt.pos, syntaxErr.Pos)
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/samber/lo"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenMetric
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: []rune(input)}
+}
+
+func isMetricChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == ':'
+}
+
+// next returns the next token of the input
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: l.pos + 1}, nil
+	}
+	start := l.pos
+	r := l.input[l.pos]
+	switch {
+	case strings.ContainsRune("+-*/", r):
+		l.pos++
+		return token{kind: tokenOperator, text: string(r), pos: start + 1}, nil
+	case r == '(':
+		l.pos++
+		return token{kind: tokenLeftParen, text: "(", pos: start + 1}, nil
+	case r == ')':
+		l.pos++
+		return token{kind: tokenRightParen, text: ")", pos: start + 1}, nil
+	case r == '"':
+		l.pos++
+		for l.pos < len(l.input) && l.input[l.pos] != '"' {
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			return token{}, &SyntaxError{Pos: start + 1, Msg: "unterminated metric id"}
+		}
+		l.pos++
+		id := string(l.input[start+1 : l.pos-1])
+		if id == "" {
+			return token{}, &SyntaxError{Pos: start + 1, Msg: "empty metric id"}
+		}
+		return token{kind: tokenMetric, text: id, pos: start + 1}, nil
+	case unicode.IsDigit(r) || r == '.':
+		for l.pos < len(l.input) && (unicode.IsDigit(l.input[l.pos]) || l.input[l.pos] == '.') {
+			l.pos++
+		}
+		return token{kind: tokenNumber, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+	case isMetricChar(r):
+		for l.pos < len(l.input) && isMetricChar(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenMetric, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+	default:
+		return token{}, &SyntaxError{Pos: start + 1, Msg: fmt.Sprintf("unexpected character %q", r)}
+	}
+}
+
+// parser is a recursive descent parser with the following grammar:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | metric | "(" expr ")"
+type parser struct {
+	lexer   *lexer
+	tok     token
+	metrics []string
+}
+
+func (p *parser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenOperator && (p.tok.text == "+" || p.tok.text == "-") {
+		op := p.tok.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.tok.kind == tokenOperator && (p.tok.text == "*" || p.tok.text == "/") {
+		op := p.tok.text[0]
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.tok.kind == tokenOperator && p.tok.text == "-" {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return negate{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenNumber:
+		v, err := strconv.ParseFloat(tok.text, 64)
+		if err != nil {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("invalid number %q", tok.text)}
+		}
+		return number(v), p.next()
+	case tokenMetric:
+		if !lo.Contains(p.metrics, tok.text) {
+			p.metrics = append(p.metrics, tok.text)
+		}
+		return metric(tok.text), p.next()
+	case tokenLeftParen:
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		n, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if p.tok.kind != tokenRightParen {
+			return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("expected \")\", got %s", p.tok)}
+		}
+		return n, p.next()
+	default:
+		return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a number, metric or \"(\", got %s", tok)}
+	}
+}
@@ -2,11 +2,17 @@ package framer
 
 import (
 	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
 )
 
 type FormatDisplayNameInput struct {
@@ -37,15 +43,17 @@ func (f FormatDisplayNameInput) ToMap() map[string]string {
 	return dict
 }
 
-func formatDisplayName(input FormatDisplayNameInput) string {
+// formatDisplayName executes the display name template; it returns an empty display name and the error if the
+// template is invalid
+func formatDisplayName(input FormatDisplayNameInput) (string, error) {
 	if input.DisplayName == "" {
-		return ""
+		return "", nil
 	}
 	s, err := parseDisplayNameExpr(input.ToMap(), input.DisplayName)
 	if err != nil {
-		return ""
+		return "", err
 	}
-	return s
+	return s, nil
 }
 
 type Arg struct {
@@ -53,14 +61,87 @@ type Arg struct {
 	Value string
 }
 
-func parseTemplate(alias string) (*template.Template, error) {
-	t := template.New("alias")
-	text := strings.Replace(alias, "{{", "{{.", -1)
-	return t.Parse(text)
+// displayNameFuncs are the functions of a display name template. The value is the last argument of each function,
+// so they can be used in a pipeline, e.g. {{metric | replace "_" " " | title}}
+func displayNameFuncs(ctx map[string]string) template.FuncMap {
+	return template.FuncMap{
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+		"title": title,
+		"replace": func(old, new, s string) string {
+			return strings.ReplaceAll(s, old, new)
+		},
+		"regexReplace": func(pattern, repl, s string) (string, error) {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return "", err
+			}
+			return re.ReplaceAllString(s, repl), nil
+		},
+		"default": func(def, s string) string {
+			if s == "" {
+				return def
+			}
+			return s
+		},
+		"truncate": func(n int, s string) string {
+			runes := []rune(s)
+			if n < 0 || len(runes) <= n {
+				return s
+			}
+			return string(runes[:n])
+		},
+		"number": func(format, s string) (string, error) {
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return "", fmt.Errorf("number: %q is not a number", s)
+			}
+			return fmt.Sprintf(format, f), nil
+		},
+		// label returns the value of a key which is not a valid template identifier, e.g. {{label "line-id"}}
+		"label": func(key string) string {
+			return ctx[key]
+		},
+	}
+}
+
+// title returns s with the first letter of each word in upper case
+func title(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if i == 0 || unicode.IsSpace(runes[i-1]) || runes[i-1] == '-' || runes[i-1] == '_' {
+			runes[i] = unicode.ToTitle(r)
+		}
+	}
+	return string(runes)
+}
+
+// templateKeywords are the identifiers which are not a key in an action like {{metric}}
+var templateKeywords = []string{"else", "end", "break", "continue", "nil", "true", "false"}
+
+// keyAction matches an action (or the first command of a pipeline) which is a bare key, e.g. {{metric}}, {{line-id}}
+// or {{ site | upper }}. A bare function name is not a valid command, so keys like {{label}} do not clash with the
+// functions.
+var keyAction = regexp.MustCompile(`\{\{(-?\s*)([A-Za-z_][\w-]*)(\s*(?:\||-?\}\}))`)
+
+// rewriteKeys rewrites the bare keys of the display name to a label lookup, e.g. {{metric}} to {{label "metric"}}
+func rewriteKeys(alias string) string {
+	return keyAction.ReplaceAllStringFunc(alias, func(action string) string {
+		groups := keyAction.FindStringSubmatch(action)
+		if lo.Contains(templateKeywords, groups[2]) {
+			return action
+		}
+		return "{{" + groups[1] + "label " + strconv.Quote(groups[2]) + groups[3]
+	})
+}
+
+func parseTemplate(alias string, funcs template.FuncMap) (*template.Template, error) {
+	return template.New("displayName").Funcs(funcs).Option("missingkey=zero").Parse(rewriteKeys(alias))
 }
 
 func parseDisplayNameExpr(ctx map[string]string, alias string) (string, error) {
-	t, err := parseTemplate(alias)
+	funcs := displayNameFuncs(ctx)
+	t, err := parseTemplate(alias, funcs)
 	if err != nil {
 		return "", err
 	}
@@ -73,3 +154,25 @@ func parseDisplayNameExpr(ctx map[string]string, alias string) (string, error) {
 
 	return b.String(), nil
 }
+
+// displayNameNotice returns the notice of an invalid display name
+func displayNameNotice(err error) data.Notice {
+	return data.Notice{
+		Severity: data.NoticeSeverityWarning,
+		Text:     fmt.Sprintf("invalid display name: %s", err),
+	}
+}
+
+// addDisplayNameNotice adds the notice of an invalid display name to the frame, unless it already has it
+func addDisplayNameNotice(frame *data.Frame, err error) {
+	if err == nil {
+		return
+	}
+	notice := displayNameNotice(err)
+	if frame.Meta == nil {
+		frame.Meta = &data.FrameMeta{}
+	}
+	if !lo.Contains(frame.Meta.Notices, notice) {
+		frame.Meta.Notices = append(frame.Meta.Notices, notice)
+	}
+}
@@ -4,18 +4,26 @@ import (
 	"testing"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestFormatDisplayName(t *testing.T) {
 	t.Run("DisplayNameFormatter", func(t *testing.T) {
 		t.Run("should return an empty string if display name is not specified", func(t *testing.T) {
-			res := formatDisplayName(FormatDisplayNameInput{MetricID: "foo"})
+			res, err := formatDisplayName(FormatDisplayNameInput{MetricID: "foo"})
+			assert.NoError(t, err)
 			assert.Empty(t, res)
 		})
-		t.Run("should return an error empty string", func(t *testing.T) {
-			res := formatDisplayName(FormatDisplayNameInput{DisplayName: "{{.Foo}}"})
+		t.Run("should return an empty string for an unknown key", func(t *testing.T) {
+			res, err := formatDisplayName(FormatDisplayNameInput{DisplayName: "{{.Foo}}"})
+			assert.NoError(t, err)
+			assert.Empty(t, res)
+		})
+		t.Run("should return an error and an empty string for an invalid template", func(t *testing.T) {
+			res, err := formatDisplayName(FormatDisplayNameInput{DisplayName: "{{metric | shout}}"})
+			assert.EqualError(t, err, `template: displayName:1: function "shout" not defined`)
 			assert.Empty(t, res)
 		})
 		t.Run("should return a formatted display name", func(t *testing.T) {
@@ -40,8 +48,63 @@ func TestFormatDisplayName(t *testing.T) {
 				}},
 			}
 
-			res := formatDisplayName(input)
+			res, err := formatDisplayName(input)
+			assert.NoError(t, err)
 			assert.Equal(t, ">>a-b-c-d<<", res)
 		})
 	})
 }
+
+func TestFormatDisplayName_Functions(t *testing.T) {
+	input := FormatDisplayNameInput{
+		MetricID:   "machine_temperature",
+		FieldName:  "value",
+		Dimensions: []models.Dimension{{Key: "line-id", Value: "L1"}, {Key: "site", Value: "amsterdam"}},
+		Labels:     []*pb.Label{{Key: "threshold", Value: "72.456"}, {Key: "shift", Value: ""}},
+	}
+	tests := []struct {
+		displayName string
+		want        string
+	}{
+		{displayName: "{{metric}} {{ field }}", want: "machine_temperature value"},
+		{displayName: "{{.metric}}", want: "machine_temperature"},
+		{displayName: "{{site | upper}} {{metric | lower}}", want: "AMSTERDAM machine_temperature"},
+		{displayName: `{{metric | replace "_" " " | title}}`, want: "Machine Temperature"},
+		{displayName: `{{metric | regexReplace "^machine_(.*)$" "$1"}}`, want: "temperature"},
+		{displayName: `{{shift | default "all shifts"}}`, want: "all shifts"},
+		{displayName: `{{site | truncate 3}}`, want: "ams"},
+		{displayName: `{{threshold | number "%.1f"}}`, want: "72.5"},
+		{displayName: `{{line-id}} / {{label "line-id"}}`, want: "L1 / L1"},
+		{displayName: `{{if eq .site "amsterdam"}}AMS{{else}}{{site}}{{end}}`, want: "AMS"},
+		{displayName: `{{- metric -}}`, want: "machine_temperature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.displayName, func(t *testing.T) {
+			input.DisplayName = tt.displayName
+			res, err := formatDisplayName(input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, res)
+		})
+	}
+
+	t.Run("a function error is returned", func(t *testing.T) {
+		input.DisplayName = `{{site | number "%.1f"}}`
+		_, err := formatDisplayName(input)
+		assert.ErrorContains(t, err, `number: "amsterdam" is not a number`)
+	})
+}
+
+func TestMetricHistory_DisplayNameNotice(t *testing.T) {
+	res := MetricHistory{
+		GetMetricHistoryResponse: &pb.GetMetricHistoryResponse{
+			Frames: []*pb.Frame{{Metric: "temperature", Fields: []*pb.Field{{Name: "min"}, {Name: "max"}}}},
+		},
+		Query: models.MetricHistoryQuery{MetricBaseQuery: models.MetricBaseQuery{DisplayName: "{{metric | shout}}"}},
+	}
+	frames, err := res.Frames()
+	assert.NoError(t, err)
+	if assert.Len(t, frames, 1) {
+		assert.Equal(t, []data.Notice{{Severity: data.NoticeSeverityWarning, Text: `invalid display name: template: displayName:1: function "shout" not defined`}}, frames[0].Meta.Notices)
+		assert.Nil(t, frames[0].Fields[1].Config)
+	}
+}
@@ -0,0 +1,40 @@
+package framer
+
+import (
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// Enrich sets the unit and the description of the numeric fields from the metric catalog (by metric id) if the
+// server did not return them. A frame is named by the label of its metric, so a field without a display name is shown
+// by the label instead of the metric id; the frame names are the metric ids until the frames are enriched.
+func Enrich(frames data.Frames, catalog map[string]models.MetricDefinition) data.Frames {
+	if len(catalog) == 0 {
+		return frames
+	}
+	for _, frame := range frames {
+		metric, ok := catalog[frame.Name]
+		if !ok {
+			continue
+		}
+		for _, fld := range frame.Fields {
+			if !fld.Type().Numeric() {
+				continue
+			}
+			if fld.Config == nil {
+				fld.Config = &data.FieldConfig{}
+			}
+			if fld.Config.Unit == "" {
+				fld.Config.Unit = metric.Unit
+			}
+			if fld.Config.Description == "" {
+				fld.Config.Description = metric.Description
+			}
+		}
+		if metric.Label != "" {
+			frame.Name = metric.Label
+		}
+	}
+	return frames
+}
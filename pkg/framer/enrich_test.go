@@ -0,0 +1,36 @@
+package framer
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrich(t *testing.T) {
+	catalog := map[string]models.MetricDefinition{
+		"temperature": {Value: "temperature", Description: "Motor temperature", Unit: "celsius"},
+		"pressure":    {Value: "pressure", Label: "Oil pressure"},
+	}
+	withUnit := data.NewField("max", nil, []float64{})
+	withUnit.Config = &data.FieldConfig{Unit: "fahrenheit"}
+	frames := data.Frames{
+		data.NewFrame("temperature", data.NewField("time", nil, []time.Time{}), data.NewField("value", nil, []float64{}), withUnit),
+		data.NewFrame("pressure", data.NewField("time", nil, []time.Time{}), data.NewField("value", nil, []float64{})),
+	}
+
+	res := Enrich(frames, catalog)
+	assert.Nil(t, res[0].Fields[0].Config, "the time field is not enriched")
+	assert.Equal(t, &data.FieldConfig{Unit: "celsius", Description: "Motor temperature"}, res[0].Fields[1].Config)
+	assert.Equal(t, &data.FieldConfig{Unit: "fahrenheit", Description: "Motor temperature"}, res[0].Fields[2].Config, "the unit of the response is not replaced")
+	assert.Equal(t, &data.FieldConfig{}, res[1].Fields[1].Config)
+	assert.Equal(t, "temperature", res[0].Name, "a metric without a label keeps its id")
+	assert.Equal(t, "Oil pressure", res[1].Name, "the frame is named by the label of the metric")
+
+	unknown := data.Frames{data.NewFrame("humidity", data.NewField("value", nil, []float64{}))}
+	res = Enrich(unknown, catalog)
+	assert.Nil(t, res[0].Fields[0].Config, "a metric which is not in the catalog is not enriched")
+	assert.Equal(t, "humidity", res[0].Name)
+}
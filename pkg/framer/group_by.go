@@ -0,0 +1,178 @@
+package framer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// aggregate returns the aggregation of a set of values
+func aggregate(aggregation models.Aggregation, values []float64) (float64, error) {
+	switch aggregation {
+	case models.AggregationSum:
+		return lo.Sum(values), nil
+	case models.AggregationAvg:
+		return lo.Sum(values) / float64(len(values)), nil
+	case models.AggregationMin:
+		return lo.Min(values), nil
+	case models.AggregationMax:
+		return lo.Max(values), nil
+	case models.AggregationCount:
+		return float64(len(values)), nil
+	default:
+		return math.NaN(), status.Errorf(codes.InvalidArgument, "unknown aggregation %q", aggregation)
+	}
+}
+
+type group struct {
+	name   string
+	labels data.Labels
+	meta   *data.FrameMeta
+	times  map[int64]time.Time
+	fields []*groupField
+}
+
+type groupField struct {
+	name   string
+	config *data.FieldConfig
+	// values are the values of all series of the group by timestamp (in unix nanoseconds)
+	values map[int64][]float64
+}
+
+func (g *group) field(fld *data.Field) *groupField {
+	for _, f := range g.fields {
+		if f.name == fld.Name {
+			return f
+		}
+	}
+	f := &groupField{name: fld.Name, values: map[int64][]float64{}}
+	if fld.Config != nil {
+		// the display name of a single series does not apply to the group
+		cfg := *fld.Config
+		cfg.DisplayNameFromDS = ""
+		f.config = &cfg
+	}
+	g.fields = append(g.fields, f)
+	return f
+}
+
+// GroupBy combines the numeric fields of the frames into one frame per metric and group. A group is identified by the
+// values of the group by keys in the labels of a field; the labels of the resulting fields are the keys which are
+// present in the labels of the series. The period label of a comparison query is always part of the group.
+// The series of a group are aligned on their timestamps; missing values are ignored by the aggregation.
+func GroupBy(frames data.Frames, groupBy models.GroupBy) (data.Frames, error) {
+	if _, err := aggregate(groupBy.Aggregation, []float64{0}); err != nil {
+		return nil, err
+	}
+
+	// the series of a time shifted or compared period are never combined with the series of the query period
+	keys := groupBy.Keys
+	if !lo.Contains(keys, models.PeriodLabel) {
+		keys = append(append([]string{}, keys...), models.PeriodLabel)
+	}
+
+	groups := map[string]*group{}
+	for _, frame := range frames {
+		times := frameTimes(frame)
+		if times == nil {
+			continue
+		}
+		for _, fld := range frame.Fields {
+			if !fld.Type().Numeric() {
+				continue
+			}
+			labels := data.Labels{}
+			for _, key := range keys {
+				if value, ok := fld.Labels[key]; ok {
+					labels[key] = value
+				}
+			}
+			key := frame.Name + labels.String()
+			g, exists := groups[key]
+			if !exists {
+				g = &group{name: frame.Name, labels: labels, times: map[int64]time.Time{}}
+				groups[key] = g
+			}
+			g.addMeta(frame.Meta)
+
+			f := g.field(fld)
+			for i, v := range nullableValues(fld) {
+				t := times[i].UnixNano()
+				g.times[t] = times[i]
+				if v != nil {
+					f.values[t] = append(f.values[t], *v)
+				}
+			}
+		}
+	}
+
+	res := make(data.Frames, 0, len(groups))
+	for _, key := range lo.Keys(groups) {
+		res = append(res, groups[key].frame(groupBy))
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		return res[i].Fields[1].Labels.String() < res[j].Fields[1].Labels.String()
+	})
+	return res, nil
+}
+
+// addMeta retains the notices of the frames of the group
+func (g *group) addMeta(meta *data.FrameMeta) {
+	if meta == nil {
+		return
+	}
+	if g.meta == nil {
+		g.meta = &data.FrameMeta{Type: meta.Type, Custom: meta.Custom, PreferredVisualization: meta.PreferredVisualization, ExecutedQueryString: meta.ExecutedQueryString}
+	}
+	for _, n := range meta.Notices {
+		if !lo.ContainsBy(g.meta.Notices, func(item data.Notice) bool { return item.Text == n.Text }) {
+			g.meta.Notices = append(g.meta.Notices, n)
+		}
+	}
+}
+
+func (g *group) frame(groupBy models.GroupBy) *data.Frame {
+	keys := lo.Keys(g.times)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	times := make([]time.Time, len(keys))
+	for i, k := range keys {
+		times[i] = g.times[k]
+	}
+	frame := data.NewFrame(g.name, data.NewField("time", nil, times))
+	for _, f := range g.fields {
+		values := make([]*float64, len(keys))
+		for i, k := range keys {
+			if len(f.values[k]) > 0 {
+				v, _ := aggregate(groupBy.Aggregation, f.values[k])
+				values[i] = &v
+			}
+		}
+		fld := data.NewField(f.name, g.labels.Copy(), values)
+		fld.Config = f.config
+		frame.Fields = append(frame.Fields, fld)
+	}
+
+	if g.meta == nil {
+		g.meta = &data.FrameMeta{}
+	}
+	executed := fmt.Sprintf("groupBy: %s by %s", groupBy.Aggregation, strings.Join(groupBy.Keys, ", "))
+	if g.meta.ExecutedQueryString != "" {
+		executed = g.meta.ExecutedQueryString + "\n" + executed
+	}
+	g.meta.ExecutedQueryString = executed
+	frame.Meta = g.meta
+	return frame
+}
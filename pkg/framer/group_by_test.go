@@ -0,0 +1,96 @@
+package framer
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// seriesFrame returns a temperature frame with a value for each second
+func seriesFrame(labels data.Labels, seconds []int64, values []float64) *data.Frame {
+	times := make([]time.Time, len(seconds))
+	for i, s := range seconds {
+		times[i] = time.Unix(s, 0)
+	}
+	fld := data.NewField("value", labels, values)
+	fld.Config = &data.FieldConfig{Unit: "celsius", DisplayNameFromDS: "temperature " + labels["machine"]}
+	return data.NewFrame("temperature", data.NewField("time", nil, times), fld)
+}
+
+func TestGroupBy(t *testing.T) {
+	frames := data.Frames{
+		seriesFrame(data.Labels{"line": "1", "machine": "a"}, []int64{0, 10, 20}, []float64{1, 2, 3}),
+		seriesFrame(data.Labels{"line": "1", "machine": "b"}, []int64{10, 20, 30}, []float64{4, 5, 6}),
+		seriesFrame(data.Labels{"line": "2", "machine": "c"}, []int64{0, 10}, []float64{7, 8}),
+	}
+
+	tests := []struct {
+		aggregation models.Aggregation
+		line1       []*float64
+		line2       []*float64
+	}{
+		{aggregation: models.AggregationSum, line1: ptrs(1.0, 6.0, 8.0, 6.0), line2: ptrs(7.0, 8.0)},
+		{aggregation: models.AggregationAvg, line1: ptrs(1.0, 3.0, 4.0, 6.0), line2: ptrs(7.0, 8.0)},
+		{aggregation: models.AggregationMin, line1: ptrs(1.0, 2.0, 3.0, 6.0), line2: ptrs(7.0, 8.0)},
+		{aggregation: models.AggregationMax, line1: ptrs(1.0, 4.0, 5.0, 6.0), line2: ptrs(7.0, 8.0)},
+		{aggregation: models.AggregationCount, line1: ptrs(1.0, 2.0, 2.0, 1.0), line2: ptrs(1.0, 1.0)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.aggregation), func(t *testing.T) {
+			res, err := GroupBy(frames, models.GroupBy{Keys: []string{"line"}, Aggregation: tt.aggregation})
+			assert.NoError(t, err)
+			if assert.Len(t, res, 2) {
+				assert.Equal(t, tt.line1, fieldValues(res[0].Fields[1]))
+				assert.Equal(t, tt.line2, fieldValues(res[1].Fields[1]))
+				assert.Equal(t, data.Labels{"line": "1"}, res[0].Fields[1].Labels)
+				assert.Equal(t, data.Labels{"line": "2"}, res[1].Fields[1].Labels)
+				assert.Equal(t, 4, res[0].Fields[0].Len())
+			}
+		})
+	}
+
+	t.Run("should label a group with the keys of its series", func(t *testing.T) {
+		res, err := GroupBy(frames, models.GroupBy{Keys: []string{"line", "site"}, Aggregation: models.AggregationSum})
+		assert.NoError(t, err)
+		assert.Equal(t, data.Labels{"line": "1"}, res[0].Fields[1].Labels, "a key which is not a label of the series is omitted")
+		assert.Equal(t, "celsius", res[0].Fields[1].Config.Unit)
+		assert.Empty(t, res[0].Fields[1].Config.DisplayNameFromDS)
+		assert.Equal(t, "groupBy: sum by line, site", res[0].Meta.ExecutedQueryString)
+	})
+
+	t.Run("should combine all series without keys", func(t *testing.T) {
+		res, err := GroupBy(frames, models.GroupBy{Aggregation: models.AggregationCount})
+		assert.NoError(t, err)
+		if assert.Len(t, res, 1) {
+			assert.Empty(t, res[0].Fields[1].Labels)
+			assert.Equal(t, ptrs(2.0, 3.0, 2.0, 1.0), fieldValues(res[0].Fields[1]))
+		}
+	})
+
+	t.Run("should not combine the series of different periods", func(t *testing.T) {
+		compared := data.Frames{
+			seriesFrame(data.Labels{"line": "1", "machine": "a"}, []int64{0, 10}, []float64{1, 2}),
+			seriesFrame(data.Labels{"line": "1", "machine": "b"}, []int64{0, 10}, []float64{3, 4}),
+			seriesFrame(data.Labels{"line": "1", "machine": "a", models.PeriodLabel: "1w"}, []int64{0, 10}, []float64{10, 20}),
+			seriesFrame(data.Labels{"line": "1", "machine": "b", models.PeriodLabel: "1w"}, []int64{0, 10}, []float64{30, 40}),
+		}
+		res, err := GroupBy(compared, models.GroupBy{Keys: []string{"line"}, Aggregation: models.AggregationSum})
+		assert.NoError(t, err)
+		if assert.Len(t, res, 2) {
+			assert.Equal(t, data.Labels{"line": "1"}, res[0].Fields[1].Labels)
+			assert.Equal(t, ptrs(4.0, 6.0), fieldValues(res[0].Fields[1]))
+			assert.Equal(t, data.Labels{"line": "1", models.PeriodLabel: "1w"}, res[1].Fields[1].Labels)
+			assert.Equal(t, ptrs(40.0, 60.0), fieldValues(res[1].Fields[1]))
+		}
+	})
+
+	t.Run("should fail for an unknown aggregation", func(t *testing.T) {
+		_, err := GroupBy(frames, models.GroupBy{Keys: []string{"line"}, Aggregation: "median"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -4,20 +4,25 @@ import (
 	"strings"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
 type MetricAggregate struct {
 	*pb.GetMetricAggregateResponse
-	Query models.MetricBaseQuery
+	Query   models.MetricBaseQuery
+	Notices []data.Notice
+}
+
+func (f MetricAggregate) GetNotices() []data.Notice {
+	return f.Notices
 }
 
 func (f MetricAggregate) Frames() (data.Frames, error) {
-	return convertToDataFrames(f), nil
+	return applyTransformations(convertToDataFrames(f), f.Query.Transformations)
 }
 
-func (f MetricAggregate) FormatDisplayName(frame *pb.Frame, fld *pb.Field) string {
+func (f MetricAggregate) FormatDisplayName(frame *pb.Frame, fld *pb.Field) (string, error) {
 	var args []Arg
 
 	for key, value := range f.Query.Options {
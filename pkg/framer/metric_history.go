@@ -4,16 +4,17 @@ import (
 	"strings"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
 type MetricHistory struct {
 	*pb.GetMetricHistoryResponse
-	Query models.MetricHistoryQuery
+	Query   models.MetricHistoryQuery
+	Notices []data.Notice
 }
 
-func (f MetricHistory) FormatDisplayName(frame *pb.Frame, fld *pb.Field) string {
+func (f MetricHistory) FormatDisplayName(frame *pb.Frame, fld *pb.Field) (string, error) {
 	var args []Arg
 	for key, value := range f.Query.Options {
 		args = append(args, Arg{Key: strings.ToLower(key), Value: value.Label})
@@ -28,6 +29,10 @@ func (f MetricHistory) FormatDisplayName(frame *pb.Frame, fld *pb.Field) string
 	})
 }
 
+func (f MetricHistory) GetNotices() []data.Notice {
+	return f.Notices
+}
+
 func (f MetricHistory) Frames() (data.Frames, error) {
-	return convertToDataFrames(f), nil
+	return applyTransformations(convertToDataFrames(f), f.Query.Transformations)
 }
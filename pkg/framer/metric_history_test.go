@@ -6,7 +6,7 @@ import (
 	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -135,3 +135,17 @@ func TestMetricHistory_Frames(t *testing.T) {
 	})
 
 }
+
+func TestMetricHistory_NoticesWithoutFrames(t *testing.T) {
+	notice := data.Notice{Severity: data.NoticeSeverityWarning, Text: "The result is truncated"}
+	sut := MetricHistory{
+		GetMetricHistoryResponse: &pb.GetMetricHistoryResponse{},
+		Notices:                  []data.Notice{notice},
+	}
+
+	res, err := sut.Frames()
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, []data.Notice{notice}, res[0].Meta.Notices)
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"time"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
@@ -29,11 +29,16 @@ func (f MetricValue) Frames() (data.Frames, error) {
 			data.NewField("time", nil, []time.Time{metricFrame.Timestamp.AsTime()}),
 		}
 
+		var displayNameErr error
 		for idx := range metricFrame.Fields {
 			fld := metricFrame.Fields[idx]
 
 			dataField := convertToSingleDataField(fld)
-			dataField.SetConfig(convertToDataFieldConfig(fld.Config, f.FormatDisplayName(metricFrame, fld)))
+			displayName, err := f.FormatDisplayName(metricFrame, fld)
+			if err != nil {
+				displayNameErr = err
+			}
+			dataField.SetConfig(convertToDataFieldConfig(fld.Config, displayName))
 
 			fields = append(fields, dataField)
 		}
@@ -43,10 +48,11 @@ func (f MetricValue) Frames() (data.Frames, error) {
 			Fields: fields,
 			Meta:   convertFrameMeta(metricFrame.Meta),
 		}
+		addDisplayNameNotice(frame, displayNameErr)
 		res = append(res, frame)
 	}
 
-	return res, nil
+	return applyTransformations(res, f.Query.Transformations)
 }
 
 func convertToSingleDataField(fld *pb.SingleValueField) *data.Field {
@@ -62,7 +68,7 @@ func convertValue(fld *pb.SingleValueField) interface{} {
 	return []float64{fld.Value}
 }
 
-func (f MetricValue) FormatDisplayName(frame *pb.GetMetricValueResponse_Frame, fld *pb.SingleValueField) string {
+func (f MetricValue) FormatDisplayName(frame *pb.GetMetricValueResponse_Frame, fld *pb.SingleValueField) (string, error) {
 	var args []Arg
 	for key, value := range f.Query.Options {
 		args = append(args, Arg{Key: strings.ToLower(key), Value: value.Label})
@@ -0,0 +1,108 @@
+package framer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+)
+
+// series is a numeric field of a frame which is named by a naming strategy
+type series struct {
+	field  *data.Field
+	values map[string]string
+}
+
+// NameSeries names the series which do not have a display name according to the naming strategy. The names only
+// contain the dimensions (or labels) which differ between the series, so the series of a query with a single
+// dimension value are named by their metric. The catalog contains the metric definitions by id; a metric is named by
+// its label (or its description for the description strategy) if the catalog contains it.
+func NameSeries(frames data.Frames, strategy models.NamingStrategy, dimensions []models.Dimension, catalog map[string]models.MetricDefinition) data.Frames {
+	if strategy == "" || strategy == models.NamingStrategyField {
+		return frames
+	}
+	var all []series
+	for _, frame := range frames {
+		for _, fld := range frame.Fields {
+			if !fld.Type().Numeric() || (fld.Config != nil && fld.Config.DisplayNameFromDS != "") {
+				continue
+			}
+			input := FormatDisplayNameInput{
+				MetricID:   frame.Name,
+				FieldName:  fld.Name,
+				Dimensions: dimensions,
+				Labels: lo.MapToSlice(fld.Labels, func(k, v string) *pb.Label {
+					return &pb.Label{Key: k, Value: v}
+				}),
+			}
+			all = append(all, series{field: fld, values: input.ToMap()})
+		}
+	}
+	if len(all) == 0 {
+		return frames
+	}
+
+	keys := differingKeys(all)
+	if strategy != models.NamingStrategyLabels {
+		dimensionKeys := lo.Map(dimensions, func(d models.Dimension, _ int) string { return d.Key })
+		keys = lo.Filter(keys, func(k string, _ int) bool { return lo.Contains(dimensionKeys, k) })
+	}
+	fieldsDiffer := lo.SomeBy(all, func(s series) bool { return s.values["field"] != all[0].values["field"] })
+
+	for _, s := range all {
+		var parts []string
+		pairs := lo.Map(keys, func(k string, _ int) string { return k + "=" + s.values[k] })
+		switch {
+		case strategy == models.NamingStrategyLabels && len(pairs) > 0:
+			parts = append(parts, strings.Join(pairs, ", "))
+		default:
+			parts = append(parts, seriesName(s, strategy, catalog))
+			if fieldsDiffer {
+				parts = append(parts, s.values["field"])
+			}
+			if len(pairs) > 0 {
+				parts = append(parts, fmt.Sprintf("{%s}", strings.Join(pairs, ", ")))
+			}
+		}
+		if s.field.Config == nil {
+			s.field.Config = &data.FieldConfig{}
+		}
+		s.field.Config.DisplayNameFromDS = strings.Join(parts, " ")
+	}
+	return frames
+}
+
+// seriesName returns the description of the metric of the series for the description strategy, or its label or
+// metric id
+func seriesName(s series, strategy models.NamingStrategy, catalog map[string]models.MetricDefinition) string {
+	metric := catalog[s.values["metric"]]
+	switch {
+	case strategy == models.NamingStrategyDescription && metric.Description != "":
+		return metric.Description
+	case metric.Label != "":
+		return metric.Label
+	default:
+		return s.values["metric"]
+	}
+}
+
+// differingKeys returns the sorted keys (except metric and field) of which the value differs between the series
+func differingKeys(all []series) []string {
+	var keys []string
+	for _, s := range all {
+		for k := range s.values {
+			if k == "metric" || k == "field" || lo.Contains(keys, k) {
+				continue
+			}
+			if lo.SomeBy(all, func(other series) bool { return other.values[k] != s.values[k] }) {
+				keys = append(keys, k)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,81 @@
+package framer
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func namedFrames() data.Frames {
+	frame := func(metric string, labels data.Labels, fields ...string) *data.Frame {
+		frame := data.NewFrame(metric, data.NewField("time", nil, []time.Time{}))
+		for _, name := range fields {
+			frame.Fields = append(frame.Fields, data.NewField(name, labels, []float64{}))
+		}
+		return frame
+	}
+	return data.Frames{
+		frame("temperature", data.Labels{"line": "L1", "machine": "m1", "unit": "c"}, "value"),
+		frame("temperature", data.Labels{"line": "L1", "machine": "m2", "unit": "c"}, "value"),
+		frame("temperature", data.Labels{"line": "L1", "machine": "m3", "unit": "f"}, "value"),
+	}
+}
+
+func displayNames(frames data.Frames) []string {
+	var res []string
+	for _, frame := range frames {
+		for _, fld := range frame.Fields[1:] {
+			res = append(res, lo.FromPtr(fld.Config).DisplayNameFromDS)
+		}
+	}
+	return res
+}
+
+func TestNameSeries(t *testing.T) {
+	dimensions := []models.Dimension{{Key: "line", Value: "L1"}, {Key: "machine", Value: "*"}}
+	catalog := map[string]models.MetricDefinition{"temperature": {Value: "temperature", Description: "Motor temperature"}}
+
+	tests := []struct {
+		strategy models.NamingStrategy
+		want     []string
+	}{
+		{strategy: "", want: []string{"", "", ""}},
+		{strategy: models.NamingStrategyField, want: []string{"", "", ""}},
+		{strategy: models.NamingStrategyMetricDimensions, want: []string{"temperature {machine=m1}", "temperature {machine=m2}", "temperature {machine=m3}"}},
+		{strategy: models.NamingStrategyLabels, want: []string{"machine=m1, unit=c", "machine=m2, unit=c", "machine=m3, unit=f"}},
+		{strategy: models.NamingStrategyDescription, want: []string{"Motor temperature {machine=m1}", "Motor temperature {machine=m2}", "Motor temperature {machine=m3}"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			res := NameSeries(namedFrames(), tt.strategy, dimensions, catalog)
+			assert.Equal(t, tt.want, displayNames(res))
+		})
+	}
+
+	t.Run("the field name is added if it differs between the series", func(t *testing.T) {
+		frames := data.Frames{data.NewFrame("output",
+			data.NewField("time", nil, []time.Time{}),
+			data.NewField("min", data.Labels{"line": "L1"}, []float64{}),
+			data.NewField("max", data.Labels{"line": "L1"}, []float64{}),
+		)}
+		res := NameSeries(frames, models.NamingStrategyLabels, []models.Dimension{{Key: "line", Value: "L1"}}, nil)
+		assert.Equal(t, []string{"output min", "output max"}, displayNames(res), "the labels strategy falls back to the metric without differing labels")
+	})
+
+	t.Run("a metric is named by its label", func(t *testing.T) {
+		catalog := map[string]models.MetricDefinition{"temperature": {Value: "temperature", Label: "Temperature"}}
+		res := NameSeries(namedFrames(), models.NamingStrategyDescription, dimensions, catalog)
+		assert.Equal(t, []string{"Temperature {machine=m1}", "Temperature {machine=m2}", "Temperature {machine=m3}"}, displayNames(res), "the label is used if the metric has no description")
+	})
+
+	t.Run("a display name is not replaced", func(t *testing.T) {
+		frames := namedFrames()
+		frames[0].Fields[1].Config = &data.FieldConfig{DisplayNameFromDS: "first machine"}
+		res := NameSeries(frames, models.NamingStrategyMetricDimensions, dimensions, nil)
+		assert.Equal(t, []string{"first machine", "temperature {machine=m2}", "temperature {machine=m3}"}, displayNames(res))
+	})
+}
@@ -0,0 +1,147 @@
+package framer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// reduce returns the reduction of the values of a series; a series without values is reduced to NaN
+func reduce(reducer models.Reducer, values []float64) (float64, error) {
+	if len(values) == 0 {
+		return math.NaN(), nil
+	}
+	switch reducer {
+	case models.ReducerLast:
+		return values[len(values)-1], nil
+	case models.ReducerMean:
+		return lo.Sum(values) / float64(len(values)), nil
+	case models.ReducerMax:
+		return lo.Max(values), nil
+	case models.ReducerSum:
+		return lo.Sum(values), nil
+	default:
+		return math.NaN(), status.Errorf(codes.InvalidArgument, "unknown reducer %q", reducer)
+	}
+}
+
+// rankValue returns the reduction of the values of a numeric field by which the series is ranked
+func rankValue(fld *data.Field, reducer models.Reducer) (float64, error) {
+	var values []float64
+	for _, v := range nullableValues(fld) {
+		if v != nil && !math.IsNaN(*v) {
+			values = append(values, *v)
+		}
+	}
+	return reduce(reducer, values)
+}
+
+// rankedSeries is a numeric field of a frame
+type rankedSeries struct {
+	frame int
+	field int
+	value float64
+}
+
+// Rank returns the top or bottom N series (numeric fields) ranked by the reduction of their values. Series without
+// values are ranked last. The frames are rebuilt with the fields of their ranked series and the fields which are not
+// numeric, e.g. the time field, and are ordered by their best ranked series; frames without a ranked series are
+// removed. The ranking is noted in the meta of the returned frames; the first frame has a notice.
+func Rank(frames data.Frames, ranking models.Ranking) (data.Frames, error) {
+	if ranking.Order != models.RankingOrderTop && ranking.Order != models.RankingOrderBottom {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown ranking order %q", ranking.Order)
+	}
+	if ranking.Limit <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "ranking limit should be greater than 0")
+	}
+	if _, err := reduce(ranking.Reducer, []float64{0}); err != nil {
+		return nil, err
+	}
+
+	var series []rankedSeries
+	for i, frame := range frames {
+		for j, fld := range frame.Fields {
+			if !fld.Type().Numeric() {
+				continue
+			}
+			v, _ := rankValue(fld, ranking.Reducer)
+			series = append(series, rankedSeries{frame: i, field: j, value: v})
+		}
+	}
+	ranked := append([]rankedSeries{}, series...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].value, ranked[j].value
+		if math.IsNaN(b) {
+			return !math.IsNaN(a)
+		}
+		if ranking.Order == models.RankingOrderBottom {
+			return a < b
+		}
+		return a > b
+	})
+	if len(ranked) > ranking.Limit {
+		ranked = ranked[:ranking.Limit]
+	}
+
+	// the ranks of the series of each frame, and the frames in the order of their best ranked series
+	ranks := map[int]map[int]int{}
+	var order []int
+	for i, rs := range ranked {
+		if _, exists := ranks[rs.frame]; !exists {
+			ranks[rs.frame] = map[int]int{}
+			order = append(order, rs.frame)
+		}
+		ranks[rs.frame][rs.field] = i + 1
+	}
+
+	notice := data.Notice{
+		Severity: data.NoticeSeverityInfo,
+		Text:     fmt.Sprintf("showing the %s %d of %d series by %s", ranking.Order, len(ranked), len(series), ranking.Reducer),
+	}
+	res := make(data.Frames, len(order))
+	for i, idx := range order {
+		frame := rankedFrame(frames[idx], ranks[idx])
+		if frame.Meta == nil {
+			frame.Meta = &data.FrameMeta{}
+		}
+		positions := lo.Values(ranks[idx])
+		sort.Ints(positions)
+		numbers := lo.Map(positions, func(rank int, _ int) string { return fmt.Sprintf("#%d", rank) })
+		executed := fmt.Sprintf("ranking: %s %d by %s (%s)", ranking.Order, ranking.Limit, ranking.Reducer, strings.Join(numbers, ", "))
+		if frame.Meta.ExecutedQueryString != "" {
+			executed = frame.Meta.ExecutedQueryString + "\n" + executed
+		}
+		frame.Meta.ExecutedQueryString = executed
+		if i == 0 {
+			frame.AppendNotices(notice)
+		}
+		res[i] = frame
+	}
+	return res, nil
+}
+
+// rankedFrame returns the frame with the fields which are not numeric and the ranked numeric fields in order of their rank
+func rankedFrame(frame *data.Frame, ranks map[int]int) *data.Frame {
+	res := &data.Frame{Name: frame.Name, RefID: frame.RefID, Meta: frame.Meta}
+	var ranked []int
+	for j, fld := range frame.Fields {
+		if !fld.Type().Numeric() {
+			res.Fields = append(res.Fields, fld)
+		} else if _, ok := ranks[j]; ok {
+			ranked = append(ranked, j)
+		}
+	}
+	sort.Slice(ranked, func(a, b int) bool { return ranks[ranked[a]] < ranks[ranked[b]] })
+	for _, j := range ranked {
+		res.Fields = append(res.Fields, frame.Fields[j])
+	}
+	return res
+}
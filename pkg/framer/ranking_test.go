@@ -0,0 +1,92 @@
+package framer
+
+import (
+	"testing"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func machines(frames data.Frames) []string {
+	res := make([]string, len(frames))
+	for i, frame := range frames {
+		res[i] = frame.Fields[1].Labels["machine"]
+	}
+	return res
+}
+
+func TestRank(t *testing.T) {
+	series := func() data.Frames {
+		return data.Frames{
+			seriesFrame(data.Labels{"machine": "a"}, []int64{0, 10, 20}, []float64{1, 9, 2}),
+			seriesFrame(data.Labels{"machine": "b"}, []int64{0, 10, 20}, []float64{4, 5, 6}),
+			seriesFrame(data.Labels{"machine": "c"}, []int64{0, 10, 20}, []float64{3, 3, 3}),
+			seriesFrame(data.Labels{"machine": "d"}, nil, nil),
+		}
+	}
+
+	tests := []struct {
+		ranking models.Ranking
+		want    []string
+	}{
+		{ranking: models.Ranking{Order: models.RankingOrderTop, Limit: 2, Reducer: models.ReducerLast}, want: []string{"b", "c"}},
+		{ranking: models.Ranking{Order: models.RankingOrderTop, Limit: 2, Reducer: models.ReducerMax}, want: []string{"a", "b"}},
+		{ranking: models.Ranking{Order: models.RankingOrderBottom, Limit: 2, Reducer: models.ReducerMean}, want: []string{"c", "a"}},
+		{ranking: models.Ranking{Order: models.RankingOrderTop, Limit: 1, Reducer: models.ReducerSum}, want: []string{"b"}},
+		{ranking: models.Ranking{Order: models.RankingOrderBottom, Limit: 10, Reducer: models.ReducerSum}, want: []string{"c", "a", "b", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.ranking.Order)+" by "+string(tt.ranking.Reducer), func(t *testing.T) {
+			res, err := Rank(series(), tt.ranking)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, machines(res))
+		})
+	}
+
+	t.Run("should note the ranking in the frame meta", func(t *testing.T) {
+		res, err := Rank(series(), models.Ranking{Order: models.RankingOrderTop, Limit: 2, Reducer: models.ReducerMax})
+		assert.NoError(t, err)
+		assert.Equal(t, "ranking: top 2 by max (#1)", res[0].Meta.ExecutedQueryString)
+		assert.Equal(t, "ranking: top 2 by max (#2)", res[1].Meta.ExecutedQueryString)
+		if assert.Len(t, res[0].Meta.Notices, 1) {
+			assert.Equal(t, "showing the top 2 of 4 series by max", res[0].Meta.Notices[0].Text)
+		}
+		assert.Empty(t, res[1].Meta.Notices)
+	})
+
+	t.Run("should rank each series of a frame", func(t *testing.T) {
+		frame := seriesFrame(data.Labels{"machine": "a"}, []int64{0, 10}, []float64{1, 2})
+		frame.Fields = append(frame.Fields,
+			data.NewField("value", data.Labels{"machine": "b"}, []float64{8, 9}),
+			data.NewField("state", nil, []string{"on", "off"}),
+			data.NewField("value", data.Labels{"machine": "c"}, []float64{5, 5}),
+		)
+		frames := data.Frames{frame, seriesFrame(data.Labels{"machine": "d"}, []int64{0, 10}, []float64{6, 7})}
+
+		res, err := Rank(frames, models.Ranking{Order: models.RankingOrderTop, Limit: 3, Reducer: models.ReducerMax})
+		assert.NoError(t, err)
+		if assert.Len(t, res, 2) {
+			labels := lo.Map(res[0].Fields[1:], func(fld *data.Field, _ int) string { return fld.Labels["machine"] + fld.Name })
+			assert.Equal(t, []string{"state", "bvalue", "cvalue"}, labels, "the series are ordered by rank after the other fields")
+			assert.Equal(t, "time", res[0].Fields[0].Name)
+			assert.Equal(t, "ranking: top 3 by max (#1, #3)", res[0].Meta.ExecutedQueryString)
+			assert.Equal(t, []string{"d"}, machines(res[1:]))
+			assert.Equal(t, "showing the top 3 of 4 series by max", res[0].Meta.Notices[0].Text)
+		}
+	})
+
+	t.Run("should fail for an invalid ranking", func(t *testing.T) {
+		for _, ranking := range []models.Ranking{
+			{Order: "first", Limit: 1, Reducer: models.ReducerMax},
+			{Order: models.RankingOrderTop, Limit: 0, Reducer: models.ReducerMax},
+			{Order: models.RankingOrderTop, Limit: 1, Reducer: "median"},
+		} {
+			_, err := Rank(series(), ranking)
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package framer
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// transformation transforms the values of a numeric field; it returns a description of what it did
+type transformation func(times []time.Time, values []*float64) ([]*float64, string)
+
+func newTransformation(t models.Transformation) (transformation, error) {
+	switch t.Type {
+	case models.TransformationRate:
+		return rate, nil
+	case models.TransformationDelta:
+		return delta, nil
+	case models.TransformationCumulativeSum:
+		return cumulativeSum, nil
+	case models.TransformationScale:
+		factor := 1.0
+		if t.Factor != nil {
+			factor = *t.Factor
+		}
+		return scale(factor, t.Offset), nil
+	case models.TransformationMovingAverage, models.TransformationMovingMedian:
+		w, err := models.ParseWindow(t.Window)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s: %s", t.Type, err)
+		}
+		if t.Type == models.TransformationMovingAverage {
+			return moving(w, "moving average", average), nil
+		}
+		return moving(w, "moving median", median), nil
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown transformation %q", t.Type)
+	}
+}
+
+// applyTransformations applies the transformations in order to the numeric fields of the frames. The result of a
+// transformation is a nullable field; the name, labels and config of the field are retained.
+func applyTransformations(frames data.Frames, transformations []models.Transformation) (data.Frames, error) {
+	if len(transformations) == 0 {
+		return frames, nil
+	}
+	fns := make([]transformation, len(transformations))
+	for i := range transformations {
+		fn, err := newTransformation(transformations[i])
+		if err != nil {
+			return nil, err
+		}
+		fns[i] = fn
+	}
+
+	for _, frame := range frames {
+		times := frameTimes(frame)
+		if times == nil {
+			continue
+		}
+		var executed []string
+		for _, fn := range fns {
+			var descriptions []string
+			for i, fld := range frame.Fields {
+				if !fld.Type().Numeric() {
+					continue
+				}
+				values, description := fn(times, nullableValues(fld))
+				newField := data.NewField(fld.Name, fld.Labels, values)
+				newField.Config = fld.Config
+				frame.Fields[i] = newField
+				if !lo.Contains(descriptions, description) {
+					descriptions = append(descriptions, description)
+				}
+			}
+			executed = append(executed, descriptions...)
+		}
+		if len(executed) == 0 {
+			continue
+		}
+		if frame.Meta == nil {
+			frame.Meta = &data.FrameMeta{}
+		}
+		if frame.Meta.ExecutedQueryString != "" {
+			executed = append([]string{frame.Meta.ExecutedQueryString}, executed...)
+		}
+		frame.Meta.ExecutedQueryString = strings.Join(executed, "\n")
+	}
+	return frames, nil
+}
+
+// frameTimes returns the values of the time field of a frame, or nil if the frame does not have a time field
+func frameTimes(frame *data.Frame) []time.Time {
+	for _, fld := range frame.Fields {
+		if fld.Type() != data.FieldTypeTime {
+			continue
+		}
+		times := make([]time.Time, fld.Len())
+		for i := range times {
+			times[i], _ = fld.At(i).(time.Time)
+		}
+		return times
+	}
+	return nil
+}
+
+// nullableValues returns the values of a numeric field; NaN values are returned as missing values
+func nullableValues(fld *data.Field) []*float64 {
+	values := make([]*float64, fld.Len())
+	for i := range values {
+		v, err := fld.NullableFloatAt(i)
+		if err != nil || v == nil || math.IsNaN(*v) {
+			continue
+		}
+		values[i] = v
+	}
+	return values
+}
+
+func rate(times []time.Time, values []*float64) ([]*float64, string) {
+	res := make([]*float64, len(values))
+	resets := 0
+	prev := -1
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if prev >= 0 {
+			seconds := times[i].Sub(times[prev]).Seconds()
+			increase := *v - *values[prev]
+			if increase < 0 {
+				// the counter is reset; the current value is the increase since the reset
+				increase = *v
+				resets++
+			}
+			if seconds > 0 {
+				res[i] = lo.ToPtr(increase / seconds)
+			}
+		}
+		prev = i
+	}
+	return res, fmt.Sprintf("rate: per-second rate, %d counter resets", resets)
+}
+
+func delta(_ []time.Time, values []*float64) ([]*float64, string) {
+	res := make([]*float64, len(values))
+	prev := -1
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if prev >= 0 {
+			res[i] = lo.ToPtr(*v - *values[prev])
+		}
+		prev = i
+	}
+	return res, "delta: difference with the previous value"
+}
+
+func cumulativeSum(_ []time.Time, values []*float64) ([]*float64, string) {
+	res := make([]*float64, len(values))
+	sum := 0.0
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		sum += *v
+		res[i] = lo.ToPtr(sum)
+	}
+	return res, "cumulativeSum: running total"
+}
+
+func scale(factor, offset float64) transformation {
+	return func(_ []time.Time, values []*float64) ([]*float64, string) {
+		res := make([]*float64, len(values))
+		for i, v := range values {
+			if v != nil {
+				res[i] = lo.ToPtr(*v*factor + offset)
+			}
+		}
+		return res, fmt.Sprintf("scale: value * %g + %g", factor, offset)
+	}
+}
+
+// moving applies a reducer to the values in the trailing window of each value
+func moving(w models.Window, name string, reduce func(values []float64) float64) transformation {
+	return func(times []time.Time, values []*float64) ([]*float64, string) {
+		res := make([]*float64, len(values))
+		for i := range values {
+			if values[i] == nil {
+				continue
+			}
+			var windowValues []float64
+			for j := i; j >= 0; j-- {
+				if w.Points > 0 && i-j >= w.Points {
+					break
+				}
+				if w.Duration > 0 && times[i].Sub(times[j]) >= w.Duration {
+					break
+				}
+				if values[j] != nil {
+					windowValues = append(windowValues, *values[j])
+				}
+			}
+			res[i] = lo.ToPtr(reduce(windowValues))
+		}
+		return res, fmt.Sprintf("%s: window of %s", name, w)
+	}
+}
+
+func average(values []float64) float64 {
+	return lo.Sum(values) / float64(len(values))
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
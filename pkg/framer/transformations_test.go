@@ -0,0 +1,126 @@
+package framer
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func transformationFrame(values ...float64) *data.Frame {
+	times := make([]time.Time, len(values))
+	for i := range times {
+		times[i] = time.Unix(int64(i*10), 0)
+	}
+	fld := data.NewField("counter", data.Labels{"zone": "a"}, values)
+	fld.Config = &data.FieldConfig{Unit: "kWh"}
+	return data.NewFrame("energy",
+		data.NewField("time", nil, times),
+		fld,
+		data.NewField("state", nil, make([]string, len(values))),
+	)
+}
+
+func fieldValues(fld *data.Field) []*float64 {
+	res := make([]*float64, fld.Len())
+	for i := range res {
+		res[i], _ = fld.NullableFloatAt(i)
+	}
+	return res
+}
+
+func ptrs(values ...interface{}) []*float64 {
+	res := make([]*float64, len(values))
+	for i, v := range values {
+		if f, ok := v.(float64); ok {
+			res[i] = lo.ToPtr(f)
+		}
+	}
+	return res
+}
+
+func Test_applyTransformations(t *testing.T) {
+	tests := []struct {
+		name            string
+		transformations []models.Transformation
+		values          []float64
+		want            []*float64
+		executed        string
+	}{
+		{
+			name:            "rate handles counter resets",
+			transformations: []models.Transformation{{Type: models.TransformationRate}},
+			values:          []float64{0, 10, 30, 5, 25},
+			want:            ptrs(nil, 1.0, 2.0, 0.5, 2.0),
+			executed:        "rate: per-second rate, 1 counter resets",
+		},
+		{
+			name:            "delta",
+			transformations: []models.Transformation{{Type: models.TransformationDelta}},
+			values:          []float64{1, 4, 2},
+			want:            ptrs(nil, 3.0, -2.0),
+			executed:        "delta: difference with the previous value",
+		},
+		{
+			name:            "moving average over a number of points",
+			transformations: []models.Transformation{{Type: models.TransformationMovingAverage, Window: "2"}},
+			values:          []float64{2, 4, 8},
+			want:            ptrs(2.0, 3.0, 6.0),
+			executed:        "moving average: window of 2 points",
+		},
+		{
+			name:            "moving median over a duration",
+			transformations: []models.Transformation{{Type: models.TransformationMovingMedian, Window: "30s"}},
+			values:          []float64{1, 100, 2, 3},
+			want:            ptrs(1.0, 50.5, 2.0, 3.0),
+			executed:        "moving median: window of 30s",
+		},
+		{
+			name:            "cumulative sum",
+			transformations: []models.Transformation{{Type: models.TransformationCumulativeSum}},
+			values:          []float64{1, 2, 3},
+			want:            ptrs(1.0, 3.0, 6.0),
+			executed:        "cumulativeSum: running total",
+		},
+		{
+			name:            "transformations are applied in order",
+			transformations: []models.Transformation{{Type: models.TransformationDelta}, {Type: models.TransformationScale, Factor: lo.ToPtr(2.0), Offset: 1}},
+			values:          []float64{1, 4, 2},
+			want:            ptrs(nil, 7.0, -3.0),
+			executed:        "delta: difference with the previous value\nscale: value * 2 + 1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := applyTransformations(data.Frames{transformationFrame(tt.values...)}, tt.transformations)
+			assert.NoError(t, err)
+
+			fld := res[0].Fields[1]
+			assert.Equal(t, tt.want, fieldValues(fld))
+			assert.Equal(t, data.Labels{"zone": "a"}, fld.Labels)
+			assert.Equal(t, "kWh", fld.Config.Unit)
+			assert.Equal(t, data.FieldTypeString, res[0].Fields[2].Type())
+			assert.Equal(t, tt.executed, res[0].Meta.ExecutedQueryString)
+		})
+	}
+
+	t.Run("missing values are skipped", func(t *testing.T) {
+		frame := transformationFrame(0, 0, 0)
+		frame.Fields[1] = data.NewField("counter", nil, []*float64{lo.ToPtr(1.0), nil, lo.ToPtr(3.0)})
+		res, err := applyTransformations(data.Frames{frame}, []models.Transformation{{Type: models.TransformationDelta}})
+		assert.NoError(t, err)
+		assert.Equal(t, ptrs(nil, nil, 2.0), fieldValues(res[0].Fields[1]))
+	})
+
+	t.Run("invalid transformations are rejected", func(t *testing.T) {
+		for _, tr := range []models.Transformation{{Type: "integral"}, {Type: models.TransformationMovingAverage, Window: "0"}, {Type: models.TransformationMovingMedian, Window: "forever"}} {
+			_, err := applyTransformations(data.Frames{transformationFrame(1)}, []models.Transformation{tr})
+			assert.Equal(t, codes.InvalidArgument, status.Code(err), tr.Type)
+		}
+	})
+}
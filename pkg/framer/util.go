@@ -1,13 +1,15 @@
 package framer
 
 import (
+	"math"
 	"sort"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/samber/lo"
 
 	fields2 "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/framer/fields"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 )
 
 func convertToDataField(fld *pb.Field) *data.Field {
@@ -64,17 +66,39 @@ func convertToDataFieldLabels(labels []*pb.Label) data.Labels {
 	return dataLabels
 }
 
+// convertValues returns the values of a field. The backend API does not have null values: a missing value is NaN (numeric
+// fields) or an empty string (string fields), e.g. a value which is missing in one of the pages of a paginated result
+// or an inserted bucket. A field which contains missing values is converted to a nullable field.
 func convertValues(fld *pb.Field) interface{} {
 	if len(fld.StringValues) > 0 {
-		return fld.StringValues
+		if !lo.Contains(fld.StringValues, "") {
+			return fld.StringValues
+		}
+		values := make([]*string, len(fld.StringValues))
+		for i := range fld.StringValues {
+			if fld.StringValues[i] != "" {
+				values[i] = &fld.StringValues[i]
+			}
+		}
+		return values
+	}
+	if !lo.ContainsBy(fld.Values, math.IsNaN) {
+		return fld.Values
 	}
-	return fld.Values
+	values := make([]*float64, len(fld.Values))
+	for i := range fld.Values {
+		if !math.IsNaN(fld.Values[i]) {
+			values[i] = &fld.Values[i]
+		}
+	}
+	return values
 }
 
 type framesResponse interface {
 	GetFrames() []*pb.Frame
 	GetNextToken() string
-	FormatDisplayName(frame *pb.Frame, fld *pb.Field) string
+	GetNotices() []data.Notice
+	FormatDisplayName(frame *pb.Frame, fld *pb.Field) (string, error)
 }
 
 func convertToDataFrames(response framesResponse) data.Frames {
@@ -97,10 +121,15 @@ func convertToDataFrames(response framesResponse) data.Frames {
 			timeField,
 		}
 
+		var displayNameErr error
 		for idx := range metricFrame.Fields {
 			fld := metricFrame.Fields[idx]
 			dataField := convertToDataField(fld)
-			dataField.SetConfig(convertToDataFieldConfig(fld.Config, response.FormatDisplayName(metricFrame, fld)))
+			displayName, err := response.FormatDisplayName(metricFrame, fld)
+			if err != nil {
+				displayNameErr = err
+			}
+			dataField.SetConfig(convertToDataFieldConfig(fld.Config, displayName))
 
 			fields = append(fields, dataField)
 		}
@@ -111,12 +140,13 @@ func convertToDataFrames(response framesResponse) data.Frames {
 		}
 
 		frame.Meta = convertFrameMeta(metricFrame.Meta)
+		addDisplayNameNotice(frame, displayNameErr)
 
 		res = append(res, frame)
 	}
 
 	// Sort frames by the "Name" field
-	sort.Slice(res, func(i, j int) bool {
+	sort.SliceStable(res, func(i, j int) bool {
 		return res[i].Name < res[j].Name
 	})
 
@@ -134,6 +164,10 @@ func convertToDataFrames(response framesResponse) data.Frames {
 				NextToken: response.GetNextToken(),
 			}
 		}
+		frame.Meta.Notices = append(frame.Meta.Notices, response.GetNotices()...)
+	} else if notices := response.GetNotices(); len(notices) > 0 {
+		// without frames the notices (e.g. of a truncated result) are returned in an empty frame
+		res = append(res, &data.Frame{Meta: &data.FrameMeta{Notices: notices}})
 	}
 
 	return res
@@ -1,10 +1,11 @@
 package framer
 
 import (
+	"math"
 	"reflect"
 	"testing"
 
-	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v3"
+	pb "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/proto/v5"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
 )
@@ -101,3 +102,23 @@ func Test_convertToDataFieldConfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_convertValues(t *testing.T) {
+	t.Run("numeric values", func(t *testing.T) {
+		res := convertValues(&pb.Field{Values: []float64{1, 2}})
+		assert.Equal(t, []float64{1, 2}, res)
+	})
+	t.Run("string values", func(t *testing.T) {
+		res := convertValues(&pb.Field{StringValues: []string{"a", "b"}})
+		assert.Equal(t, []string{"a", "b"}, res)
+	})
+	t.Run("missing values are converted to null values", func(t *testing.T) {
+		one, three := float64(1), float64(3)
+		res := convertValues(&pb.Field{Values: []float64{1, math.NaN(), 3}})
+		assert.Equal(t, []*float64{&one, nil, &three}, res)
+	})
+	t.Run("missing strings are converted to null values", func(t *testing.T) {
+		a, c := "a", "c"
+		assert.Equal(t, []*string{&a, nil, &c}, convertValues(&pb.Field{StringValues: []string{"a", "", "c"}}))
+	})
+}
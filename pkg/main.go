@@ -3,12 +3,16 @@ package main
 import (
 	"os"
 
+	backendapi "bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/backend"
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/plugin"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
+	// the metrics of the plugin are gathered along with the metrics of the SDK
+	prometheus.DefaultGatherer = prometheus.Gatherers{prometheus.DefaultGatherer, backendapi.MetricsRegistry}
 
 	if err := datasource.Manage("simple-grpc-datasource", plugin.NewDatasource, datasource.ManageOpts{}); err != nil {
 		log.DefaultLogger.Error(err.Error())
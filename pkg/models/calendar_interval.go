@@ -0,0 +1,76 @@
+package models
+
+import (
+	"time"
+)
+
+// CalendarInterval is an aggregation interval which is aligned to calendar boundaries in a time zone
+type CalendarInterval string
+
+const (
+	CalendarIntervalNone    CalendarInterval = ""
+	CalendarIntervalDay     CalendarInterval = "day"
+	CalendarIntervalWeek    CalendarInterval = "week"
+	CalendarIntervalMonth   CalendarInterval = "month"
+	CalendarIntervalQuarter CalendarInterval = "quarter"
+)
+
+// IsValid returns whether the calendar interval is known
+func (c CalendarInterval) IsValid() bool {
+	switch c {
+	case CalendarIntervalNone, CalendarIntervalDay, CalendarIntervalWeek, CalendarIntervalMonth, CalendarIntervalQuarter:
+		return true
+	default:
+		return false
+	}
+}
+
+// Truncate returns the start of the interval which contains t in the location loc. Weeks start on monday.
+// The result is calculated on the local wall clock, so days which are shortened or extended by a DST transition
+// still start at midnight.
+func (c CalendarInterval) Truncate(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	y, m, d := t.Date()
+	switch c {
+	case CalendarIntervalDay:
+		return time.Date(y, m, d, 0, 0, 0, 0, loc)
+	case CalendarIntervalWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, loc)
+	case CalendarIntervalMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, loc)
+	case CalendarIntervalQuarter:
+		return time.Date(y, ((m-1)/3)*3+1, 1, 0, 0, 0, 0, loc)
+	default:
+		return t
+	}
+}
+
+// Next returns the start of the interval which follows the interval that starts at t
+func (c CalendarInterval) Next(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch c {
+	case CalendarIntervalDay:
+		return time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+	case CalendarIntervalWeek:
+		return time.Date(y, m, d+7, 0, 0, 0, 0, t.Location())
+	case CalendarIntervalMonth:
+		return time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location())
+	case CalendarIntervalQuarter:
+		return time.Date(y, m+3, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return t
+	}
+}
+
+// Intervals returns the start of all intervals which overlap with the time range [from, to)
+func (c CalendarInterval) Intervals(from, to time.Time, loc *time.Location) []time.Time {
+	if c == CalendarIntervalNone {
+		return nil
+	}
+	var res []time.Time
+	for t := c.Truncate(from, loc); t.Before(to); t = c.Next(t) {
+		res = append(res, t)
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalendarInterval(t *testing.T) {
+	amsterdam, err := time.LoadLocation("Europe/Amsterdam")
+	if !assert.NoError(t, err) {
+		return
+	}
+	// wednesday 30 october 2024, three days after the end of daylight saving time
+	ts := time.Date(2024, 10, 30, 15, 30, 0, 0, amsterdam)
+
+	tests := []struct {
+		interval CalendarInterval
+		start    time.Time
+		next     time.Time
+	}{
+		{CalendarIntervalDay, time.Date(2024, 10, 30, 0, 0, 0, 0, amsterdam), time.Date(2024, 10, 31, 0, 0, 0, 0, amsterdam)},
+		{CalendarIntervalWeek, time.Date(2024, 10, 28, 0, 0, 0, 0, amsterdam), time.Date(2024, 11, 4, 0, 0, 0, 0, amsterdam)},
+		{CalendarIntervalMonth, time.Date(2024, 10, 1, 0, 0, 0, 0, amsterdam), time.Date(2024, 11, 1, 0, 0, 0, 0, amsterdam)},
+		{CalendarIntervalQuarter, time.Date(2024, 10, 1, 0, 0, 0, 0, amsterdam), time.Date(2025, 1, 1, 0, 0, 0, 0, amsterdam)},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.interval), func(t *testing.T) {
+			start := tt.interval.Truncate(ts.UTC(), amsterdam)
+			assert.True(t, tt.start.Equal(start), "expected %s, got %s", tt.start, start)
+			assert.True(t, tt.next.Equal(tt.interval.Next(start)))
+		})
+	}
+
+	t.Run("a day which contains a DST transition starts at midnight", func(t *testing.T) {
+		days := CalendarIntervalDay.Intervals(time.Date(2024, 10, 26, 12, 0, 0, 0, amsterdam), time.Date(2024, 10, 28, 12, 0, 0, 0, amsterdam), amsterdam)
+		if assert.Len(t, days, 3) {
+			assert.Equal(t, 25*time.Hour, days[2].Sub(days[1]))
+			for _, d := range days {
+				assert.Equal(t, 0, d.Hour())
+			}
+		}
+	})
+}
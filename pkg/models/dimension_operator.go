@@ -0,0 +1,47 @@
+package models
+
+import (
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+// DimensionOperator compares the values of a dimension with the value(s) of a query
+type DimensionOperator string
+
+const (
+	// DimensionOperatorEqual selects the value of the dimension; this is the default
+	DimensionOperatorEqual DimensionOperator = "="
+	// DimensionOperatorNotEqual selects all values of the dimension except the value
+	DimensionOperatorNotEqual DimensionOperator = "!="
+	// DimensionOperatorRegex selects all values of the dimension which match the (fully anchored) regular expression
+	DimensionOperatorRegex DimensionOperator = "=~"
+	// DimensionOperatorIn selects the values of the dimension which are in the set of values
+	DimensionOperatorIn DimensionOperator = "in"
+)
+
+// HasOperator returns whether the dimension is compared with another operator than equality
+func (d Dimension) HasOperator() bool {
+	return d.Operator != "" && d.Operator != DimensionOperatorEqual
+}
+
+// Matcher returns a function which returns whether a value of the dimension matches the operator of the dimension
+func (d Dimension) Matcher() (func(value string) bool, error) {
+	switch d.Operator {
+	case "", DimensionOperatorEqual:
+		return func(value string) bool { return value == d.Value }, nil
+	case DimensionOperatorNotEqual:
+		return func(value string) bool { return value != d.Value }, nil
+	case DimensionOperatorRegex:
+		re, err := regexp.Compile("^(?:" + d.Value + ")$")
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid regular expression for dimension %s", d.Key)
+		}
+		return re.MatchString, nil
+	case DimensionOperatorIn:
+		return func(value string) bool { return lo.Contains(d.Values, value) }, nil
+	default:
+		return nil, errors.Errorf("unknown operator %q for dimension %s", d.Operator, d.Key)
+	}
+}
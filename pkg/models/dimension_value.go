@@ -10,6 +10,12 @@ type DimensionValueDefinition struct {
 	Value       string `json:"value"`
 	Label       string `json:"label"`
 	Description string `json:"description"`
+	// Group is the (optional) category of the dimension value
+	Group string `json:"group,omitempty"`
+	// Icon is the (optional) name of a grafana icon
+	Icon string `json:"icon,omitempty"`
+	// Color is the (optional) color hint of the dimension value
+	Color string `json:"color,omitempty"`
 }
 
 type GetDimensionValueResponse struct {
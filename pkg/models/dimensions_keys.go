@@ -9,6 +9,12 @@ type DimensionKeyDefinition struct {
 	Value       string `json:"value"`
 	Label       string `json:"label"`
 	Description string `json:"description"`
+	// Group is the (optional) category of the dimension key
+	Group string `json:"group,omitempty"`
+	// Icon is the (optional) name of a grafana icon
+	Icon string `json:"icon,omitempty"`
+	// Color is the (optional) color hint of the dimension key
+	Color string `json:"color,omitempty"`
 }
 
 type GetDimensionKeysResponse struct {
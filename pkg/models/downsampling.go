@@ -0,0 +1,24 @@
+package models
+
+// DownsamplingAlgorithm is the algorithm which is used to reduce the number of values of a metric history
+type DownsamplingAlgorithm string
+
+const (
+	DownsamplingNone DownsamplingAlgorithm = ""
+	// DownsamplingLTTB selects the points which retain the visual shape of the data (Largest-Triangle-Three-Buckets)
+	DownsamplingLTTB DownsamplingAlgorithm = "lttb"
+	// DownsamplingMinMax selects the min and max value of each time bucket
+	DownsamplingMinMax DownsamplingAlgorithm = "minmax"
+	// DownsamplingAverage returns the average value of each time bucket
+	DownsamplingAverage DownsamplingAlgorithm = "avg"
+)
+
+// IsValid returns whether the downsampling algorithm is known
+func (a DownsamplingAlgorithm) IsValid() bool {
+	switch a {
+	case DownsamplingNone, DownsamplingLTTB, DownsamplingMinMax, DownsamplingAverage:
+		return true
+	default:
+		return false
+	}
+}
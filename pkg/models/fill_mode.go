@@ -0,0 +1,26 @@
+package models
+
+// FillMode determines how the buckets which are missing in an aggregate result are filled
+type FillMode string
+
+const (
+	FillModeNone FillMode = ""
+	// FillModeNull inserts missing values, so Grafana does not connect the values on both sides of a gap
+	FillModeNull FillMode = "null"
+	// FillModeZero inserts the value zero
+	FillModeZero FillMode = "zero"
+	// FillModePrevious repeats the last known value
+	FillModePrevious FillMode = "previous"
+	// FillModeLinear interpolates between the values on both sides of a gap
+	FillModeLinear FillMode = "linear"
+)
+
+// IsValid returns whether the fill mode is known
+func (m FillMode) IsValid() bool {
+	switch m {
+	case FillModeNone, FillModeNull, FillModeZero, FillModePrevious, FillModeLinear:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,30 @@
+package models
+
+// Aggregation combines the values of multiple series at the same timestamp
+type Aggregation string
+
+const (
+	AggregationSum   Aggregation = "sum"
+	AggregationAvg   Aggregation = "avg"
+	AggregationMin   Aggregation = "min"
+	AggregationMax   Aggregation = "max"
+	AggregationCount Aggregation = "count"
+)
+
+// GroupBy combines the series of a query into one series per group of dimension values
+type GroupBy struct {
+	// Keys are the dimension keys (labels) which identify a group; all other labels are aggregated
+	Keys []string `json:"keys"`
+	// Aggregation combines the values of the series of a group
+	Aggregation Aggregation `json:"aggregation"`
+}
+
+// IsValid returns whether the aggregation is known
+func (a Aggregation) IsValid() bool {
+	switch a {
+	case AggregationSum, AggregationAvg, AggregationMin, AggregationMax, AggregationCount:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+// QueryLimits restricts the amount of data which is collected while paging through a query result.
+// A zero value means that there is no limit.
+type QueryLimits struct {
+	// MaxPages is the max. number of pages which are retrieved from the backend
+	MaxPages int `json:"maxPages,omitempty"`
+	// MaxPoints is the max. number of values which are collected for all fields
+	MaxPoints int64 `json:"maxPoints,omitempty"`
+	// MaxBytes is the max. size of the collected backend responses
+	MaxBytes int64 `json:"maxBytes,omitempty"`
+}
+
+// WithDefaults returns the limits with all unspecified limits taken from d
+func (l QueryLimits) WithDefaults(d QueryLimits) QueryLimits {
+	if l.MaxPages == 0 {
+		l.MaxPages = d.MaxPages
+	}
+	if l.MaxPoints == 0 {
+		l.MaxPoints = d.MaxPoints
+	}
+	if l.MaxBytes == 0 {
+		l.MaxBytes = d.MaxBytes
+	}
+	return l
+}
+
+// Within returns the limits restricted to max; a query can only lower the limits of the datasource. An unspecified
+// limit is taken from max and a limit which max does not restrict is kept as is.
+func (l QueryLimits) Within(max QueryLimits) QueryLimits {
+	l.MaxPages = lowest(l.MaxPages, max.MaxPages)
+	l.MaxPoints = lowest(l.MaxPoints, max.MaxPoints)
+	l.MaxBytes = lowest(l.MaxBytes, max.MaxBytes)
+	return l
+}
+
+// lowest returns the lowest of two limits of which a zero value means that there is no limit
+func lowest[T int | int64](a, b T) T {
+	if a <= 0 || (b > 0 && b < a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryLimits_Within(t *testing.T) {
+	max := QueryLimits{MaxPages: 100, MaxPoints: 1000}
+
+	assert.Equal(t, QueryLimits{MaxPages: 100, MaxPoints: 1000}, QueryLimits{}.Within(max), "unspecified limits are taken from the datasource")
+	assert.Equal(t, QueryLimits{MaxPages: 10, MaxPoints: 1000}, QueryLimits{MaxPages: 10}.Within(max), "a query can lower a limit")
+	assert.Equal(t, QueryLimits{MaxPages: 100, MaxPoints: 1000, MaxBytes: 50}, QueryLimits{MaxPages: 1000, MaxPoints: 5000, MaxBytes: 50}.Within(max), "a query can not raise a limit")
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/samber/lo"
+)
+
+// macroPattern matches a macro in the $__name or ${__name} form
+var macroPattern = regexp.MustCompile(`\$__(\w+)|\$\{__(\w+)\}`)
+
+// Macros are the values of the macros which are expanded by the backend, by name. Queries of alert rules and reports
+// are not interpolated by the frontend, so the backend expands these macros for all queries.
+type Macros map[string]string
+
+// NewMacros returns the macros of a data query; the time zone is UTC unless specified
+func NewMacros(pCtx backend.PluginContext, q backend.DataQuery, timeZone string) Macros {
+	if timeZone == "" {
+		timeZone = "UTC"
+	}
+	macros := Macros{
+		"from":        strconv.FormatInt(q.TimeRange.From.UnixMilli(), 10),
+		"to":          strconv.FormatInt(q.TimeRange.To.UnixMilli(), 10),
+		"interval":    FormatInterval(q.Interval),
+		"interval_ms": strconv.FormatInt(q.Interval.Milliseconds(), 10),
+		"range":       FormatInterval(q.TimeRange.Duration()),
+		"timezone":    timeZone,
+		"org_id":      strconv.FormatInt(pCtx.OrgID, 10),
+	}
+	if ds := pCtx.DataSourceInstanceSettings; ds != nil {
+		macros["datasource_id"] = strconv.FormatInt(ds.ID, 10)
+		macros["datasource_uid"] = ds.UID
+		macros["datasource_name"] = ds.Name
+	}
+	return macros
+}
+
+// FormatInterval formats a duration in the largest unit (d, h, m, s or ms) which represents it exactly, e.g. 90s or 2h
+func FormatInterval(d time.Duration) string {
+	for _, unit := range []struct {
+		duration time.Duration
+		suffix   string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	} {
+		if d != 0 && d%unit.duration == 0 {
+			return fmt.Sprintf("%d%s", d/unit.duration, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%dms", d.Milliseconds())
+}
+
+// Expand replaces the known macros in s; it returns the names of the macros which are replaced. Unknown macros are
+// left as is.
+func (m Macros) Expand(s string) (string, []string) {
+	var used []string
+	res := macroPattern.ReplaceAllStringFunc(s, func(match string) string {
+		groups := macroPattern.FindStringSubmatch(match)
+		name := groups[1] + groups[2]
+		value, ok := m[name]
+		if !ok {
+			return match
+		}
+		used = append(used, name)
+		return value
+	})
+	return res, used
+}
+
+// String returns the macros as they are listed in the executed query string, e.g. $__from=1672531200000
+func (m Macros) String() string {
+	names := lo.Keys(m)
+	sort.Strings(names)
+	return strings.Join(lo.Map(names, func(name string, _ int) string { return "$__" + name + "=" + m[name] }), ", ")
+}
+
+// ExpandMacros expands the macros in the dimension values, option values and display name of the query. It returns
+// the macros which are used by the query.
+func (q *MetricBaseQuery) ExpandMacros(macros Macros) Macros {
+	used := Macros{}
+	expand := func(s string) string {
+		res, names := macros.Expand(s)
+		for _, name := range names {
+			used[name] = macros[name]
+		}
+		return res
+	}
+	expandAll := func(values []string) []string {
+		return lo.Map(values, func(v string, _ int) string { return expand(v) })
+	}
+
+	for i, d := range q.Dimensions {
+		q.Dimensions[i].Value = expand(d.Value)
+		if len(d.Values) > 0 {
+			q.Dimensions[i].Values = expandAll(d.Values)
+		}
+	}
+	if len(q.Options) > 0 {
+		q.Options = lo.MapValues(q.Options, func(v OptionValue, _ string) OptionValue {
+			v.Value = expand(v.Value)
+			if len(v.Values) > 0 {
+				v.Values = expandAll(v.Values)
+			}
+			return v
+		})
+	}
+	q.DisplayName = expand(q.DisplayName)
+	return used
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMacros(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	macros := NewMacros(backend.PluginContext{
+		OrgID:                      2,
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{ID: 7, UID: "grpc", Name: "Factory"},
+	}, backend.DataQuery{
+		TimeRange: backend.TimeRange{From: from, To: from.Add(6 * time.Hour)},
+		Interval:  90 * time.Second,
+	}, "")
+
+	assert.Equal(t, Macros{
+		"from":            "1672531200000",
+		"to":              "1672552800000",
+		"interval":        "90s",
+		"interval_ms":     "90000",
+		"range":           "6h",
+		"timezone":        "UTC",
+		"org_id":          "2",
+		"datasource_id":   "7",
+		"datasource_uid":  "grpc",
+		"datasource_name": "Factory",
+	}, macros)
+}
+
+func TestFormatInterval(t *testing.T) {
+	assert.Equal(t, "2d", FormatInterval(48*time.Hour))
+	assert.Equal(t, "90m", FormatInterval(90*time.Minute))
+	assert.Equal(t, "1s", FormatInterval(time.Second))
+	assert.Equal(t, "1500ms", FormatInterval(1500*time.Millisecond))
+	assert.Equal(t, "0ms", FormatInterval(0))
+}
+
+func TestMetricBaseQuery_ExpandMacros(t *testing.T) {
+	macros := Macros{"from": "1000", "interval": "1m", "interval_ms": "60000", "timezone": "Europe/Amsterdam"}
+	query := MetricBaseQuery{
+		Dimensions: []Dimension{
+			{Key: "window", Value: "$__interval_ms"},
+			{Key: "shift", Values: []string{"${__timezone}", "$__user"}, Operator: DimensionOperatorIn},
+		},
+		Options:     map[string]OptionValue{"start": {Value: "$__from"}, "windows": {Values: []string{"$__interval"}}},
+		DisplayName: "{{metric}} per $__interval",
+	}
+
+	used := query.ExpandMacros(macros)
+	assert.Equal(t, Macros{"from": "1000", "interval": "1m", "interval_ms": "60000", "timezone": "Europe/Amsterdam"}, used)
+	assert.Equal(t, "60000", query.Dimensions[0].Value)
+	assert.Equal(t, []string{"Europe/Amsterdam", "$__user"}, query.Dimensions[1].Values, "unknown macros are not expanded")
+	assert.Equal(t, OptionValue{Value: "1000"}, query.Options["start"])
+	assert.Equal(t, OptionValue{Values: []string{"1m"}}, query.Options["windows"])
+	assert.Equal(t, "{{metric}} per 1m", query.DisplayName)
+	assert.Equal(t, "$__from=1000, $__interval=1m, $__interval_ms=60000, $__timezone=Europe/Amsterdam", used.String())
+}
@@ -1,18 +1,20 @@
 package models
 
 import (
-	"encoding/json"
-
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 )
 
 type MetricAggregateQuery struct {
 	MetricBaseQuery
+	// CalendarInterval aligns the aggregates to calendar days, weeks, months or quarters instead of the query interval
+	CalendarInterval CalendarInterval `json:"calendarInterval,omitempty"`
+	// FillMode fills the buckets which are missing between the start and end of the time range
+	FillMode FillMode `json:"fillMode,omitempty"`
 }
 
 func UnmarshalToMetricAggregateQuery(dq *backend.DataQuery) (*MetricAggregateQuery, error) {
 	query := &MetricAggregateQuery{}
-	if err := json.Unmarshal(dq.JSON, query); err != nil {
+	if err := unmarshalQuery(dq.JSON, query); err != nil {
 		return nil, err
 	}
 
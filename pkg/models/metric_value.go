@@ -1,8 +1,6 @@
 package models
 
 import (
-	"encoding/json"
-
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 )
 
@@ -12,7 +10,7 @@ type MetricValueQuery struct {
 
 func UnmarshalToMetricValueQuery(dq *backend.DataQuery) (*MetricValueQuery, error) {
 	query := &MetricValueQuery{}
-	if err := json.Unmarshal(dq.JSON, query); err != nil {
+	if err := unmarshalQuery(dq.JSON, query); err != nil {
 		return nil, err
 	}
 
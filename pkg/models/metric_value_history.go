@@ -1,17 +1,20 @@
 package models
 
 import (
-	"encoding/json"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 )
 
 type MetricHistoryQuery struct {
 	MetricBaseQuery
+	// TimeOrdering determines whether the oldest (ascending) or the most recent (descending) values are retrieved first
+	TimeOrdering TimeOrdering `json:"timeOrdering,omitempty"`
+	// MaxItems is the max. number of values per series; combined with a descending time ordering it returns the last N values
+	MaxItems int64 `json:"maxItems,omitempty"`
 }
 
 func UnmarshalToMetricHistoryQuery(dq *backend.DataQuery) (*MetricHistoryQuery, error) {
 	query := &MetricHistoryQuery{}
-	if err := json.Unmarshal(dq.JSON, query); err != nil {
+	if err := unmarshalQuery(dq.JSON, query); err != nil {
 		return nil, err
 	}
 
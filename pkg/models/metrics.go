@@ -9,6 +9,14 @@ type MetricDefinition struct {
 	Value       string `json:"value"`
 	Label       string `json:"label"`
 	Description string `json:"description"`
+	// Unit is the unit of measure of the metric
+	Unit string `json:"unit,omitempty"`
+	// Group is the (optional) category of the metric
+	Group string `json:"group,omitempty"`
+	// Icon is the (optional) name of a grafana icon
+	Icon string `json:"icon,omitempty"`
+	// Color is the (optional) color hint of the metric
+	Color string `json:"color,omitempty"`
 }
 
 type GetMetricsResponse struct {
@@ -0,0 +1,26 @@
+package models
+
+// NamingStrategy determines the name of a series of a query without a display name
+type NamingStrategy string
+
+const (
+	// NamingStrategyField names a series by its field name; this is the default
+	NamingStrategyField NamingStrategy = "field"
+	// NamingStrategyMetricDimensions names a series by its metric and the dimensions which differ between the series
+	NamingStrategyMetricDimensions NamingStrategy = "metricDimensions"
+	// NamingStrategyLabels names a series by the labels which differ between the series
+	NamingStrategyLabels NamingStrategy = "labels"
+	// NamingStrategyDescription names a series by the description of its metric and the dimensions which differ
+	// between the series
+	NamingStrategyDescription NamingStrategy = "description"
+)
+
+// IsValid returns whether the naming strategy is known; an empty strategy uses the default of the datasource
+func (s NamingStrategy) IsValid() bool {
+	switch s {
+	case "", NamingStrategyField, NamingStrategyMetricDimensions, NamingStrategyLabels, NamingStrategyDescription:
+		return true
+	default:
+		return false
+	}
+}
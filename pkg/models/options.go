@@ -1,7 +1,25 @@
 package models
 
+import (
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+)
+
 type Options = []Option
 
+// the types of query options
+const (
+	OptionTypeEnum      = "Enum"
+	OptionTypeBoolean   = "Boolean"
+	OptionTypeNumber    = "Number"
+	OptionTypeText      = "Text"
+	OptionTypeDuration  = "Duration"
+	OptionTypeDateRange = "DateRange"
+	OptionTypeMultiEnum = "MultiEnum"
+)
+
 type EnumValue struct {
 	ID          string `json:"id,omitempty"`
 	Label       string `json:"label,omitempty"`
@@ -16,6 +34,29 @@ type Option struct {
 	Type        string      `json:"type,omitempty"`
 	EnumValues  []EnumValue `json:"enumValues,omitempty"`
 	Required    bool        `json:"required,omitempty"`
+	// Min and Max are the bounds of a Number option, or of the length of a Text option
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+	// Step is the step size of a Number option, relative to Min
+	Step float64 `json:"step,omitempty"`
+	// Pattern is a regular expression which matches the entire value of a Text option
+	Pattern string `json:"pattern,omitempty"`
+	// DependsOn are the dependencies of the option; the option is ignored if one of them is not met
+	DependsOn []OptionDependency `json:"dependsOn,omitempty"`
+	// DefaultValue is the default value of an option which is not an enum; see EnumValue.Default
+	DefaultValue *OptionValue `json:"defaultValue,omitempty"`
+}
+
+// OptionDependency is met if the option has one of the values, or any value if no values are specified
+type OptionDependency struct {
+	OptionID string   `json:"optionId"`
+	Values   []string `json:"values,omitempty"`
+}
+
+// DateRange is the value of a DateRange option
+type DateRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
 }
 
 // GetQueryOptionsRequest defines the request for the GetQueryOptions endpoint.
@@ -31,3 +72,88 @@ type GetQueryOptionsRequest struct {
 type GetQueryOptionsResponse struct {
 	Options Options `json:"options,omitempty"`
 }
+
+// IsEmpty returns whether no value is selected
+func (v OptionValue) IsEmpty() bool {
+	return v.Value == "" && len(v.Values) == 0 && v.DateRange == nil
+}
+
+// SelectedValues returns the values of a MultiEnum option, or the value of any other option
+func (v OptionValue) SelectedValues() []string {
+	if len(v.Values) > 0 {
+		return v.Values
+	}
+	if v.Value == "" {
+		return nil
+	}
+	return []string{v.Value}
+}
+
+// String returns the value as it is selected in a GetQueryOptionsRequest; the values of a MultiEnum option are
+// separated by commas and a date range is formatted as an ISO 8601 time interval
+func (v OptionValue) String() string {
+	if v.DateRange != nil {
+		return v.DateRange.From.Format(time.RFC3339) + "/" + v.DateRange.To.Format(time.RFC3339)
+	}
+	return strings.Join(v.SelectedValues(), ",")
+}
+
+// SelectedOptions returns the options as they are selected in a GetQueryOptionsRequest
+func SelectedOptions(options map[string]OptionValue) map[string]string {
+	return lo.MapValues(options, func(value OptionValue, _ string) string { return value.String() })
+}
+
+// DependenciesMet returns whether all dependencies of the option are met by the selected options
+func (o Option) DependenciesMet(options map[string]OptionValue) bool {
+	return lo.EveryBy(o.DependsOn, func(d OptionDependency) bool {
+		value, selected := options[d.OptionID]
+		if !selected || value.IsEmpty() {
+			return false
+		}
+		return len(d.Values) == 0 || lo.Some(d.Values, value.SelectedValues())
+	})
+}
+
+// ApplyOptionDefaults returns the options with the default value of each option which is not selected. The second
+// result reports whether a default was applied.
+func ApplyOptionDefaults(options map[string]OptionValue, definitions Options) (map[string]OptionValue, bool) {
+	res := make(map[string]OptionValue, len(options))
+	for k, v := range options {
+		res[k] = v
+	}
+	applied := false
+	for _, def := range definitions {
+		if value, selected := res[def.ID]; (selected && !value.IsEmpty()) || !def.DependenciesMet(res) {
+			continue
+		}
+		if def.DefaultValue != nil {
+			res[def.ID] = *def.DefaultValue
+			applied = true
+			continue
+		}
+		defaults := lo.Filter(def.EnumValues, func(v EnumValue, _ int) bool { return v.Default })
+		switch {
+		case len(defaults) == 0:
+		case def.Type == OptionTypeMultiEnum:
+			res[def.ID] = OptionValue{Values: lo.Map(defaults, func(v EnumValue, _ int) string { return v.ID })}
+			applied = true
+		default:
+			res[def.ID] = OptionValue{Value: defaults[0].ID, Label: defaults[0].Label}
+			applied = true
+		}
+	}
+	return res, applied
+}
+
+// ApplyOptionTypes returns the options with the type of their definition; options without a definition keep an
+// empty type and are passed to the backend as text
+func ApplyOptionTypes(options map[string]OptionValue, definitions Options) map[string]OptionValue {
+	res := make(map[string]OptionValue, len(options))
+	for k, v := range options {
+		if def, ok := lo.Find(definitions, func(o Option) bool { return o.ID == k }); ok {
+			v.Type = def.Type
+		}
+		res[k] = v
+	}
+	return res
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOptionDefaults(t *testing.T) {
+	definitions := Options{
+		{ID: "0", Label: "Aggregate", Type: "Enum", Required: true, EnumValues: []EnumValue{{ID: "avg", Label: "Average", Default: true}, {ID: "max"}}},
+		{ID: "1", Label: "Interpolate", Type: "Boolean"},
+		{ID: "2", Label: "Resolution", Type: "Enum", EnumValues: []EnumValue{{ID: "low"}, {ID: "high", Label: "High", Default: true}}},
+	}
+
+	t.Run("missing options get their default value", func(t *testing.T) {
+		selected := map[string]OptionValue{"0": {Value: "max"}, "2": {Value: ""}}
+		res, applied := ApplyOptionDefaults(selected, definitions)
+		assert.True(t, applied)
+		assert.Equal(t, map[string]OptionValue{"0": {Value: "max"}, "2": {Value: "high", Label: "High"}}, res)
+		assert.Equal(t, OptionValue{}, selected["2"], "the selected options are not modified")
+	})
+
+	t.Run("an options map without missing options is unchanged", func(t *testing.T) {
+		res, applied := ApplyOptionDefaults(map[string]OptionValue{"0": {Value: "max"}, "2": {Value: "low"}}, definitions)
+		assert.False(t, applied)
+		assert.Equal(t, map[string]OptionValue{"0": {Value: "max"}, "2": {Value: "low"}}, res)
+	})
+
+	t.Run("a nil options map", func(t *testing.T) {
+		res, applied := ApplyOptionDefaults(nil, definitions)
+		assert.True(t, applied)
+		assert.Equal(t, map[string]OptionValue{"0": {Value: "avg", Label: "Average"}, "2": {Value: "high", Label: "High"}}, res)
+	})
+}
+
+func TestApplyOptionDefaults_Types(t *testing.T) {
+	definitions := Options{
+		{ID: "shifts", Type: OptionTypeMultiEnum, EnumValues: []EnumValue{{ID: "a", Default: true}, {ID: "b"}, {ID: "c", Default: true}}},
+		{ID: "percentile", Type: OptionTypeNumber, DefaultValue: &OptionValue{Value: "95"}},
+		{ID: "smoothing", Type: OptionTypeNumber, DefaultValue: &OptionValue{Value: "3"}, DependsOn: []OptionDependency{{OptionID: "shifts", Values: []string{"b"}}}},
+	}
+	res, applied := ApplyOptionDefaults(nil, definitions)
+	assert.True(t, applied)
+	assert.Equal(t, map[string]OptionValue{"shifts": {Values: []string{"a", "c"}}, "percentile": {Value: "95"}}, res, "the default of an option with an unmet dependency is not applied")
+
+	res, _ = ApplyOptionDefaults(map[string]OptionValue{"shifts": {Values: []string{"b"}}}, definitions)
+	assert.Equal(t, OptionValue{Value: "3"}, res["smoothing"])
+}
+
+func TestOptionValue_String(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "a,b", OptionValue{Values: []string{"a", "b"}}.String())
+	assert.Equal(t, "2023-01-01T00:00:00Z/2023-01-02T00:00:00Z", OptionValue{DateRange: &DateRange{From: from, To: from.AddDate(0, 0, 1)}}.String())
+	assert.Equal(t, "95", OptionValue{Value: "95", Label: "95th"}.String())
+}
@@ -12,9 +12,22 @@ const (
 	QueryMetricAggregate = "GetMetricAggregate"
 )
 
+// DimensionWildcard is the value of a dimension which selects all values of the dimension
+const DimensionWildcard = "*"
+
 type Dimension struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
+	// Values selects multiple values of the dimension; the query returns a series for each value. For the in operator
+	// these are the values of the set.
+	Values []string `json:"values,omitempty"`
+	// Operator compares the values of the dimension with the value(s); the default is equality
+	Operator DimensionOperator `json:"operator,omitempty"`
+}
+
+// IsMultiValued returns whether the dimension selects more than one value, i.e. a wildcard or a list of values
+func (d Dimension) IsMultiValued() bool {
+	return !d.HasOperator() && (d.Value == DimensionWildcard || len(d.Values) > 0)
 }
 
 type Metric struct {
@@ -24,9 +37,18 @@ type Metric struct {
 type OptionValue struct {
 	Value string `json:"value,omitempty"`
 	Label string `json:"label,omitempty"`
+	// Values are the values of a MultiEnum option
+	Values []string `json:"values,omitempty"`
+	// DateRange is the value of a DateRange option
+	DateRange *DateRange `json:"dateRange,omitempty"`
+	// Type is the type of the option, which is resolved from the option definitions of the backend before a query is
+	// executed; see ApplyOptionTypes
+	Type string `json:"-"`
 }
 
 type MetricBaseQuery struct {
+	// SchemaVersion is the version of the query model; see MigrateQuery
+	SchemaVersion int                    `json:"schemaVersion,omitempty"`
 	Dimensions    []Dimension            `json:"dimensions"`
 	Metrics       []Metric               `json:"metrics,omitempty"`
 	NextToken     string                 `json:"nextToken,omitempty"`
@@ -36,4 +58,35 @@ type MetricBaseQuery struct {
 	MaxDataPoints int64                  `json:"-"`
 	QueryType     string                 `json:"-"`
 	Options       map[string]OptionValue `json:"queryOptions,omitempty"`
+	Limits        QueryLimits            `json:"limits,omitempty"`
+	HardLimits    QueryLimits            `json:"-"`
+	// Downsampling reduces the number of values of a metric history to approximately MaxDataPoints
+	Downsampling DownsamplingAlgorithm `json:"downsampling,omitempty"`
+	// TimeShift shifts the time range of the query, e.g. -7d; the timestamps of the result are moved back onto the current time range
+	TimeShift string `json:"timeShift,omitempty"`
+	// CompareOffset adds the series of a comparison period, e.g. -7d, relative to the (shifted) time range of the query
+	CompareOffset string `json:"compareOffset,omitempty"`
+	// TimeZone is the IANA time zone of the calendar intervals, and of the days and weeks of a time shift or compare
+	// offset; the default is UTC
+	TimeZone string `json:"timeZone,omitempty"`
+	// Expression derives a new series from the metrics it references by id, e.g. good_parts / total_parts * 100
+	Expression string `json:"expression,omitempty"`
+	// ExpressionName is the name of the derived series; the default is the expression itself
+	ExpressionName string `json:"expressionName,omitempty"`
+	// JoinTolerance is the max. difference between the timestamps of metrics which are joined by the expression, e.g. 5s
+	JoinTolerance string `json:"joinTolerance,omitempty"`
+	// GroupBy combines the series of the query into one series per group of dimension values
+	GroupBy *GroupBy `json:"groupBy,omitempty"`
+	// Ranking limits the result of the query to the top or bottom N series
+	Ranking *Ranking `json:"ranking,omitempty"`
+	// Transformations are applied in order to the numeric fields of the result
+	Transformations []Transformation `json:"transformations,omitempty"`
+	// PartialResults returns the data which is collected so far if a page fails while paging through the result
+	PartialResults bool `json:"partialResults,omitempty"`
+	// RawQuery is a text query, e.g. avg(temperature{site="ams"}) by (machine); it replaces the metrics, dimensions,
+	// transformations and group by of the query
+	RawQuery string `json:"rawQuery,omitempty"`
+	// NamingStrategy names the series of a query without a display name; the default is the naming strategy of the
+	// datasource
+	NamingStrategy NamingStrategy `json:"namingStrategy,omitempty"`
 }
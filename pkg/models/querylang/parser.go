@@ -0,0 +1,440 @@
+package querylang
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+
+	"github.com/samber/lo"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenPunct
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of query"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func (t token) is(punct string) bool {
+	return t.kind == tokenPunct && t.text == punct
+}
+
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: []rune(input)}
+}
+
+func isWordChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.' || r == ':' || r == '-'
+}
+
+// next returns the next token of the input
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return token{kind: tokenEOF, pos: l.pos + 1}, nil
+	}
+	start := l.pos
+	r := l.input[l.pos]
+	switch {
+	case r == '=' || r == '!':
+		l.pos++
+		if l.pos < len(l.input) && (l.input[l.pos] == '~' || l.input[l.pos] == '=') {
+			l.pos++
+		}
+		op := string(l.input[start:l.pos])
+		if op == "!" || op == "==" {
+			return token{}, &SyntaxError{Pos: start + 1, Msg: fmt.Sprintf("unknown operator %q", op)}
+		}
+		return token{kind: tokenPunct, text: op, pos: start + 1}, nil
+	case r == '(' || r == ')' || r == '{' || r == '}' || r == '[' || r == ']' || r == ',':
+		l.pos++
+		return token{kind: tokenPunct, text: string(r), pos: start + 1}, nil
+	case r == '"':
+		l.pos++
+		for l.pos < len(l.input) && l.input[l.pos] != '"' {
+			if l.input[l.pos] == '\\' {
+				l.pos++
+			}
+			l.pos++
+		}
+		if l.pos >= len(l.input) {
+			return token{}, &SyntaxError{Pos: start + 1, Msg: "unterminated string"}
+		}
+		l.pos++
+		s, err := strconv.Unquote(string(l.input[start:l.pos]))
+		if err != nil {
+			return token{}, &SyntaxError{Pos: start + 1, Msg: "invalid string"}
+		}
+		return token{kind: tokenString, text: s, pos: start + 1}, nil
+	case isWordChar(r):
+		for l.pos < len(l.input) && isWordChar(l.input[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokenWord, text: string(l.input[start:l.pos]), pos: start + 1}, nil
+	default:
+		return token{}, &SyntaxError{Pos: start + 1, Msg: fmt.Sprintf("unexpected character %q", r)}
+	}
+}
+
+var aggregations = []models.Aggregation{
+	models.AggregationSum,
+	models.AggregationAvg,
+	models.AggregationMin,
+	models.AggregationMax,
+	models.AggregationCount,
+}
+
+var transformations = []models.TransformationType{
+	models.TransformationRate,
+	models.TransformationDelta,
+	models.TransformationMovingAverage,
+	models.TransformationMovingMedian,
+	models.TransformationCumulativeSum,
+	models.TransformationScale,
+}
+
+// parser is a recursive descent parser with the following grammar:
+//
+//	query     = ( aggregate | expr ) [ "[" duration "]" ]
+//	aggregate = aggregation [ by ] "(" expr ")" [ by ]
+//	by        = "by" "(" [ key { "," key } ] ")"
+//	expr      = function "(" expr { "," argument } ")" | selector
+//	selector  = metric [ "{" [ matcher { "," matcher } ] "}" ]
+//	matcher   = key ( "=" | "!=" | "=~" ) string | key "in" "(" string { "," string } ")"
+//
+// The arguments of a function are a window (movingAverage, movingMedian) or a factor and offset (scale).
+type parser struct {
+	lexer *lexer
+	tok   token
+	query Query
+}
+
+func (p *parser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+// expect consumes the punctuation or returns a syntax error
+func (p *parser) expect(punct string) error {
+	if !p.tok.is(punct) {
+		return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("expected %q, got %s", punct, p.tok)}
+	}
+	return p.next()
+}
+
+func (p *parser) parseQuery() (*Query, error) {
+	if err := p.parseAggregate(); err != nil {
+		return nil, err
+	}
+	if p.tok.is("[") {
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		tok := p.tok
+		if tok.kind != tokenWord {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a duration, got %s", tok)}
+		}
+		d, err := models.ParseDuration(tok.text)
+		if err != nil || d <= 0 {
+			return nil, &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("invalid duration %q", tok.text)}
+		}
+		p.query.Interval = d
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("unexpected %s", p.tok)}
+	}
+	return &p.query, nil
+}
+
+func (p *parser) parseAggregate() error {
+	tok := p.tok
+	aggregation := models.Aggregation(tok.text)
+	if tok.kind != tokenWord || !lo.Contains(aggregations, aggregation) {
+		return p.parseExpr()
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if !p.tok.is("(") && !(p.tok.kind == tokenWord && p.tok.text == "by") {
+		// a metric which has the name of an aggregation
+		return p.parseSelector(tok)
+	}
+	p.query.GroupBy = &models.GroupBy{Aggregation: aggregation}
+	if err := p.parseBy(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+	if err := p.expect(")"); err != nil {
+		return err
+	}
+	return p.parseBy()
+}
+
+func (p *parser) parseBy() error {
+	if p.tok.kind != tokenWord || p.tok.text != "by" {
+		return nil
+	}
+	if p.query.GroupBy.Keys != nil {
+		return &SyntaxError{Pos: p.tok.pos, Msg: "duplicate by clause"}
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if err := p.expect("("); err != nil {
+		return err
+	}
+	p.query.GroupBy.Keys = []string{}
+	for !p.tok.is(")") {
+		if len(p.query.GroupBy.Keys) > 0 {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		key, err := p.parseName("a dimension key")
+		if err != nil {
+			return err
+		}
+		p.query.GroupBy.Keys = append(p.query.GroupBy.Keys, key)
+	}
+	return p.next()
+}
+
+// parseName parses a word or a string
+func (p *parser) parseName(what string) (string, error) {
+	tok := p.tok
+	if tok.kind != tokenWord && tok.kind != tokenString {
+		return "", &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected %s, got %s", what, tok)}
+	}
+	return tok.text, p.next()
+}
+
+func (p *parser) parseExpr() error {
+	tok := p.tok
+	if tok.kind == tokenString {
+		if err := p.next(); err != nil {
+			return err
+		}
+		return p.parseSelector(tok)
+	}
+	if tok.kind != tokenWord {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("expected a metric or function, got %s", tok)}
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if !p.tok.is("(") {
+		return p.parseSelector(tok)
+	}
+	if lo.Contains(aggregations, models.Aggregation(tok.text)) {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("aggregation %s must be the outermost function", tok.text)}
+	}
+	fn := models.TransformationType(tok.text)
+	if !lo.Contains(transformations, fn) {
+		return &SyntaxError{Pos: tok.pos, Msg: fmt.Sprintf("unknown function %q", tok.text)}
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if err := p.parseExpr(); err != nil {
+		return err
+	}
+	// the transformations of the inner expressions are applied first
+	t, err := p.parseArguments(tok, models.Transformation{Type: fn})
+	if err != nil {
+		return err
+	}
+	p.query.Transformations = append(p.query.Transformations, t)
+	return p.expect(")")
+}
+
+// parseArguments parses the arguments of a transformation function
+func (p *parser) parseArguments(fn token, t models.Transformation) (models.Transformation, error) {
+	var args []token
+	for p.tok.is(",") {
+		if err := p.next(); err != nil {
+			return t, err
+		}
+		if p.tok.kind != tokenWord {
+			return t, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("expected an argument, got %s", p.tok)}
+		}
+		args = append(args, p.tok)
+		if err := p.next(); err != nil {
+			return t, err
+		}
+	}
+
+	number := func(arg token) (float64, error) {
+		v, err := strconv.ParseFloat(arg.text, 64)
+		if err != nil {
+			return 0, &SyntaxError{Pos: arg.pos, Msg: fmt.Sprintf("invalid number %q", arg.text)}
+		}
+		return v, nil
+	}
+	arity := func(min, max int) error {
+		if len(args) < min || len(args) > max {
+			return &SyntaxError{Pos: fn.pos, Msg: fmt.Sprintf("wrong number of arguments for %s", fn.text)}
+		}
+		return nil
+	}
+
+	switch t.Type {
+	case models.TransformationMovingAverage, models.TransformationMovingMedian:
+		if err := arity(1, 1); err != nil {
+			return t, err
+		}
+		t.Window = args[0].text
+	case models.TransformationScale:
+		if err := arity(1, 2); err != nil {
+			return t, err
+		}
+		factor, err := number(args[0])
+		if err != nil {
+			return t, err
+		}
+		t.Factor = &factor
+		if len(args) == 2 {
+			if t.Offset, err = number(args[1]); err != nil {
+				return t, err
+			}
+		}
+	default:
+		if err := arity(0, 0); err != nil {
+			return t, err
+		}
+	}
+	return t, nil
+}
+
+// parseSelector parses the dimensions of the metric token
+func (p *parser) parseSelector(metric token) error {
+	if p.query.Metric != "" {
+		return &SyntaxError{Pos: metric.pos, Msg: "a query selects a single metric"}
+	}
+	p.query.Metric = metric.text
+	if !p.tok.is("{") {
+		return nil
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	for !p.tok.is("}") {
+		if len(p.query.Dimensions) > 0 {
+			if err := p.expect(","); err != nil {
+				return err
+			}
+		}
+		if err := p.parseMatcher(); err != nil {
+			return err
+		}
+	}
+	return p.next()
+}
+
+func (p *parser) parseMatcher() error {
+	key, err := p.parseName("a dimension key")
+	if err != nil {
+		return err
+	}
+	op := p.tok
+	dimension := models.Dimension{Key: key}
+	switch {
+	case op.is("="):
+		// equality is the default operator
+	case op.is("!="):
+		dimension.Operator = models.DimensionOperatorNotEqual
+	case op.is("=~"):
+		dimension.Operator = models.DimensionOperatorRegex
+	case op.kind == tokenWord && op.text == "in":
+		dimension.Operator = models.DimensionOperatorIn
+	default:
+		return &SyntaxError{Pos: op.pos, Msg: fmt.Sprintf("expected an operator, got %s", op)}
+	}
+	if err := p.next(); err != nil {
+		return err
+	}
+	if dimension.Operator == models.DimensionOperatorIn {
+		if dimension.Values, err = p.parseSet(); err != nil {
+			return err
+		}
+	} else {
+		if p.tok.kind != tokenString {
+			return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("expected a string, got %s", p.tok)}
+		}
+		dimension.Value = p.tok.text
+		if dimension.Operator == models.DimensionOperatorRegex {
+			if _, err := dimension.Matcher(); err != nil {
+				return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("invalid regular expression %q", dimension.Value)}
+			}
+		}
+		if err := p.next(); err != nil {
+			return err
+		}
+	}
+	p.query.Dimensions = append(p.query.Dimensions, dimension)
+	return nil
+}
+
+// parseSet parses the values of the in operator
+func (p *parser) parseSet() ([]string, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	values := []string{}
+	for !p.tok.is(")") {
+		if len(values) > 0 {
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+		}
+		if p.tok.kind != tokenString {
+			return nil, &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("expected a string, got %s", p.tok)}
+		}
+		values = append(values, p.tok.text)
+		if err := p.next(); err != nil {
+			return nil, err
+		}
+	}
+	if len(values) == 0 {
+		return nil, &SyntaxError{Pos: p.tok.pos, Msg: "expected at least one value"}
+	}
+	return values, p.next()
+}
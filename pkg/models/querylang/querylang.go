@@ -0,0 +1,61 @@
+// Package querylang compiles the text query language into a structured query. A query selects a metric with its
+// dimensions, applies transformation functions and optionally combines the series with an aggregation, e.g.
+//
+//	avg(rate(energy{site="ams", machine="*"})) by (machine) [5m]
+package querylang
+
+import (
+	"fmt"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+)
+
+// SyntaxError is the error of a query which cannot be parsed
+type SyntaxError struct {
+	// Pos is the (1-based) position of the error in the query
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// Query is a parsed text query
+type Query struct {
+	Metric          string
+	Dimensions      []models.Dimension
+	Transformations []models.Transformation
+	// GroupBy is the aggregation of the query, if any
+	GroupBy *models.GroupBy
+	// Interval is the interval of the query, e.g. the bucket size of an aggregate; zero if not specified
+	Interval time.Duration
+}
+
+// Parse parses a text query; it returns a *SyntaxError if the query is invalid.
+func Parse(text string) (*Query, error) {
+	p := &parser{lexer: newLexer(text)}
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return p.parseQuery()
+}
+
+// Compile compiles a text query into a copy of the base query; the metrics, dimensions, transformations and group by
+// of the base query are replaced by the ones of the text query.
+func Compile(text string, base models.MetricBaseQuery) (models.MetricBaseQuery, error) {
+	q, err := Parse(text)
+	if err != nil {
+		return base, err
+	}
+	res := base
+	res.Metrics = []models.Metric{{MetricId: q.Metric}}
+	res.Dimensions = q.Dimensions
+	res.Transformations = q.Transformations
+	res.GroupBy = q.GroupBy
+	if q.Interval > 0 {
+		res.Interval = q.Interval
+	}
+	return res, nil
+}
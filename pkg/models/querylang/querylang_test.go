@@ -0,0 +1,144 @@
+package querylang
+
+import (
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Query
+	}{
+		{
+			query: `temperature`,
+			want:  Query{Metric: "temperature"},
+		},
+		{
+			query: `temperature{site="ams", machine="*"}`,
+			want: Query{Metric: "temperature", Dimensions: []models.Dimension{
+				{Key: "site", Value: "ams"},
+				{Key: "machine", Value: "*"},
+			}},
+		},
+		{
+			query: `temperature{machine=~"press-.*", line!="L1", site in ("ams", "rtm")}`,
+			want: Query{Metric: "temperature", Dimensions: []models.Dimension{
+				{Key: "machine", Value: "press-.*", Operator: models.DimensionOperatorRegex},
+				{Key: "line", Value: "L1", Operator: models.DimensionOperatorNotEqual},
+				{Key: "site", Values: []string{"ams", "rtm"}, Operator: models.DimensionOperatorIn},
+			}},
+		},
+		{
+			query: `avg(temperature{site="ams"}) by (machine) [5m]`,
+			want: Query{
+				Metric:     "temperature",
+				Dimensions: []models.Dimension{{Key: "site", Value: "ams"}},
+				GroupBy:    &models.GroupBy{Keys: []string{"machine"}, Aggregation: models.AggregationAvg},
+				Interval:   5 * time.Minute,
+			},
+		},
+		{
+			query: `sum by (line, "plant id") (energy)`,
+			want: Query{
+				Metric:  "energy",
+				GroupBy: &models.GroupBy{Keys: []string{"line", "plant id"}, Aggregation: models.AggregationSum},
+			},
+		},
+		{
+			query: `count(energy)`,
+			want:  Query{Metric: "energy", GroupBy: &models.GroupBy{Aggregation: models.AggregationCount}},
+		},
+		{
+			query: `max(movingAverage(rate(energy), 15m)) by (line)`,
+			want: Query{
+				Metric: "energy",
+				Transformations: []models.Transformation{
+					{Type: models.TransformationRate},
+					{Type: models.TransformationMovingAverage, Window: "15m"},
+				},
+				GroupBy: &models.GroupBy{Keys: []string{"line"}, Aggregation: models.AggregationMax},
+			},
+		},
+		{
+			query: `scale("metric \"x\"", 0.001, -2)`,
+			want: Query{
+				Metric:          `metric "x"`,
+				Transformations: []models.Transformation{{Type: models.TransformationScale, Factor: lo.ToPtr(0.001), Offset: -2}},
+			},
+		},
+		{
+			query: `sum{site="ams"}`,
+			want:  Query{Metric: "sum", Dimensions: []models.Dimension{{Key: "site", Value: "ams"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got, err := Parse(tt.query)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, *got)
+			}
+		})
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{query: ``, pos: 1, msg: "expected a metric or function, got end of query"},
+		{query: `temperature{site="ams"`, pos: 23, msg: `expected ",", got end of query`},
+		{query: `temperature{site=ams}`, pos: 18, msg: `expected a string, got "ams"`},
+		{query: `temperature{machine=~"press-("}`, pos: 22, msg: `invalid regular expression "press-("`},
+		{query: `temperature{site in ()}`, pos: 22, msg: "expected at least one value"},
+		{query: `temperature{site in "ams"}`, pos: 21, msg: `expected "(", got "ams"`},
+		{query: `temperature{site="ams}`, pos: 18, msg: "unterminated string"},
+		{query: `rate(avg(energy))`, pos: 6, msg: "aggregation avg must be the outermost function"},
+		{query: `median(energy)`, pos: 1, msg: `unknown function "median"`},
+		{query: `movingAverage(energy)`, pos: 1, msg: "wrong number of arguments for movingAverage"},
+		{query: `scale(energy, x)`, pos: 15, msg: `invalid number "x"`},
+		{query: `avg by (line) (energy) by (site)`, pos: 24, msg: "duplicate by clause"},
+		{query: `energy [5x]`, pos: 9, msg: `invalid duration "5x"`},
+		{query: `energy power`, pos: 8, msg: `unexpected "power"`},
+		{query: `energy & power`, pos: 8, msg: `unexpected character '&'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := Parse(tt.query)
+			var syntaxErr *SyntaxError
+			if assert.ErrorAs(t, err, &syntaxErr) {
+				assert.Equal(t, tt.pos, syntaxErr.Pos)
+				assert.Equal(t, tt.msg, syntaxErr.Msg)
+			}
+		})
+	}
+}
+
+func TestCompile(t *testing.T) {
+	base := models.MetricBaseQuery{
+		Metrics:         []models.Metric{{MetricId: "pressure"}},
+		Dimensions:      []models.Dimension{{Key: "site", Value: "rtm"}},
+		Transformations: []models.Transformation{{Type: models.TransformationDelta}},
+		Interval:        time.Minute,
+		TimeShift:       "-1d",
+	}
+	res, err := Compile(`sum(rate(energy{site="ams"})) by (line) [1h]`, base)
+	assert.NoError(t, err)
+	assert.Equal(t, []models.Metric{{MetricId: "energy"}}, res.Metrics)
+	assert.Equal(t, []models.Dimension{{Key: "site", Value: "ams"}}, res.Dimensions)
+	assert.Equal(t, []models.Transformation{{Type: models.TransformationRate}}, res.Transformations)
+	assert.Equal(t, &models.GroupBy{Keys: []string{"line"}, Aggregation: models.AggregationSum}, res.GroupBy)
+	assert.Equal(t, time.Hour, res.Interval)
+	assert.Equal(t, "-1d", res.TimeShift)
+
+	res, err = Compile(`energy`, base)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, res.Interval)
+	assert.Nil(t, res.GroupBy)
+}
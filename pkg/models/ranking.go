@@ -0,0 +1,43 @@
+package models
+
+// RankingOrder determines whether the highest or lowest ranked series are returned
+type RankingOrder string
+
+const (
+	RankingOrderTop    RankingOrder = "top"
+	RankingOrderBottom RankingOrder = "bottom"
+)
+
+// Reducer reduces the values of a series to a single value
+type Reducer string
+
+const (
+	ReducerLast Reducer = "last"
+	ReducerMean Reducer = "mean"
+	ReducerMax  Reducer = "max"
+	ReducerSum  Reducer = "sum"
+)
+
+// Ranking limits the result of a query to the top or bottom N series
+type Ranking struct {
+	Order RankingOrder `json:"order"`
+	// Limit is the number of series which are returned
+	Limit int `json:"limit"`
+	// Reducer determines the value of a series by which it is ranked
+	Reducer Reducer `json:"reducer"`
+}
+
+// IsValid returns whether the ranking order is known
+func (o RankingOrder) IsValid() bool {
+	return o == RankingOrderTop || o == RankingOrderBottom
+}
+
+// IsValid returns whether the reducer is known
+func (r Reducer) IsValid() bool {
+	switch r {
+	case ReducerLast, ReducerMean, ReducerMax, ReducerSum:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SchemaVersion is the current version of the query model. Stored queries of an older version are migrated before they
+// are executed:
+//
+//	0: a single metric (metricId) and a fixed aggregate type (aggregateType)
+//	1: multiple metrics (metrics) and query options (queryOptions); a query may contain the token of a page (nextToken)
+//	2: the plugin paginates the query results itself
+//
+// The query editor stamps the current version (SCHEMA_VERSION in src/types.ts) on every query it saves.
+const SchemaVersion = 2
+
+// migration upgrades the fields of a query to the next schema version
+type migration func(query map[string]json.RawMessage) error
+
+// migrations are the migrations of the query model; migrations[i] upgrades a query from version i to version i+1
+var migrations = []migration{
+	migrateToMultipleMetrics,
+	migrateToPluginPagination,
+}
+
+// MigrateQuery upgrades the JSON of a query to the current schema version
+func MigrateQuery(raw json.RawMessage) (json.RawMessage, error) {
+	var query map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &query); err != nil {
+		return nil, err
+	}
+	var version int
+	if v, ok := query["schemaVersion"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil {
+			return nil, errors.Wrap(err, "invalid schema version")
+		}
+	}
+	if version > SchemaVersion {
+		return nil, errors.Errorf("schema version %d is not supported; the max. supported version is %d", version, SchemaVersion)
+	}
+	if version == SchemaVersion {
+		return raw, nil
+	}
+	for _, migrate := range migrations[version:] {
+		if err := migrate(query); err != nil {
+			return nil, errors.Wrapf(err, "could not migrate query of schema version %d", version)
+		}
+	}
+	query["schemaVersion"] = json.RawMessage(strconv.Itoa(SchemaVersion))
+	return json.Marshal(query)
+}
+
+// unmarshalQuery migrates the JSON of a query and decodes it into the query
+func unmarshalQuery(raw json.RawMessage, query interface{}) error {
+	migrated, err := MigrateQuery(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(migrated, query)
+}
+
+// legacyAggregateTypes are the values of the aggregate type option of a version 0 query
+var legacyAggregateTypes = map[string]int{
+	"AVERAGE": 0,
+	"MAX":     1,
+	"MIN":     2,
+	"COUNT":   3,
+}
+
+// migrateToMultipleMetrics converts the metricId to a list of metrics, the aggregateType to the aggregate query option
+// and option values which are plain strings to option values
+func migrateToMultipleMetrics(query map[string]json.RawMessage) error {
+	var legacy struct {
+		MetricID      string                     `json:"metricId"`
+		AggregateType string                     `json:"aggregateType"`
+		Metrics       []Metric                   `json:"metrics"`
+		Options       map[string]json.RawMessage `json:"queryOptions"`
+	}
+	b, _ := json.Marshal(query)
+	if err := json.Unmarshal(b, &legacy); err != nil {
+		return err
+	}
+	delete(query, "metricId")
+	delete(query, "metricName")
+	delete(query, "aggregateType")
+
+	if legacy.Metrics == nil && legacy.MetricID != "" {
+		query["metrics"], _ = json.Marshal([]Metric{{MetricId: legacy.MetricID}})
+	}
+
+	options := map[string]OptionValue{}
+	for id, raw := range legacy.Options {
+		var value OptionValue
+		if err := json.Unmarshal(raw, &value.Value); err != nil {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return errors.Wrapf(err, "invalid value of query option %s", id)
+			}
+		}
+		options[id] = value
+	}
+	if len(legacy.Options) == 0 && legacy.AggregateType != "" {
+		if v, ok := legacyAggregateTypes[strings.ToUpper(legacy.AggregateType)]; ok {
+			options["0"] = OptionValue{Value: strconv.Itoa(v), Label: strings.ToLower(legacy.AggregateType)}
+		}
+	}
+	if len(options) > 0 {
+		query["queryOptions"], _ = json.Marshal(options)
+	}
+	return nil
+}
+
+// migrateToPluginPagination removes the token of a stored page; the plugin retrieves all pages of a query itself
+func migrateToPluginPagination(query map[string]json.RawMessage) error {
+	delete(query, "nextToken")
+	return nil
+}
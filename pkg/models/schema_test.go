@@ -0,0 +1,99 @@
+package models
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadQuery(t *testing.T, name string) *backend.DataQuery {
+	b, err := os.ReadFile(filepath.Join("testdata", "queries", name))
+	require.NoError(t, err)
+	return &backend.DataQuery{RefID: "A", JSON: b}
+}
+
+func TestMigrateQuery(t *testing.T) {
+	machine := []Dimension{{Key: "machine", Value: "m1"}}
+
+	tests := []struct {
+		fixture string
+		want    MetricBaseQuery
+	}{
+		{
+			fixture: "v0_metric_id.json",
+			want:    MetricBaseQuery{Metrics: []Metric{{MetricId: "temperature"}}, Dimensions: machine},
+		},
+		{
+			fixture: "v0_aggregate_type.json",
+			want: MetricBaseQuery{
+				Metrics:    []Metric{{MetricId: "temperature"}},
+				Options:    map[string]OptionValue{"0": {Value: "1", Label: "max"}},
+				Dimensions: machine,
+			},
+		},
+		{
+			fixture: "v0_string_options.json",
+			want: MetricBaseQuery{
+				Metrics:    []Metric{{MetricId: "temperature"}},
+				Options:    map[string]OptionValue{"0": {Value: "2"}, "1": {Value: "true", Label: "Yes"}},
+				Dimensions: []Dimension{},
+			},
+		},
+		{
+			fixture: "v1_next_token.json",
+			want: MetricBaseQuery{
+				Metrics:    []Metric{{MetricId: "temperature"}, {MetricId: "pressure"}},
+				Options:    map[string]OptionValue{"0": {Value: "1", Label: "max"}},
+				Dimensions: machine,
+			},
+		},
+		{
+			fixture: "v2_current.json",
+			want: MetricBaseQuery{
+				Metrics:    []Metric{{MetricId: "temperature"}},
+				Options:    map[string]OptionValue{"0": {Value: "0", Label: "average"}},
+				NextToken:  "kept",
+				Dimensions: machine,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			query, err := UnmarshalToMetricAggregateQuery(loadQuery(t, tt.fixture))
+			require.NoError(t, err)
+			tt.want.SchemaVersion = SchemaVersion
+			assert.Equal(t, tt.want, query.MetricBaseQuery)
+		})
+	}
+
+	t.Run("all query types are migrated", func(t *testing.T) {
+		history, err := UnmarshalToMetricHistoryQuery(loadQuery(t, "v0_metric_id.json"))
+		require.NoError(t, err)
+		assert.Equal(t, []Metric{{MetricId: "temperature"}}, history.Metrics)
+
+		value, err := UnmarshalToMetricValueQuery(loadQuery(t, "v1_next_token.json"))
+		require.NoError(t, err)
+		assert.Empty(t, value.NextToken)
+	})
+
+	t.Run("a query of the current schema version is not migrated", func(t *testing.T) {
+		raw := loadQuery(t, "v2_legacy_fields.json").JSON
+		migrated, err := MigrateQuery(raw)
+		require.NoError(t, err)
+		assert.Equal(t, raw, migrated)
+
+		query, err := UnmarshalToMetricAggregateQuery(loadQuery(t, "v2_legacy_fields.json"))
+		require.NoError(t, err)
+		assert.Equal(t, []Metric{{MetricId: "pressure"}}, query.Metrics)
+		assert.Empty(t, query.Options)
+	})
+
+	t.Run("a newer schema version is rejected", func(t *testing.T) {
+		_, err := UnmarshalToMetricHistoryQuery(loadQuery(t, "v3_unsupported.json"))
+		assert.ErrorContains(t, err, "schema version 3 is not supported")
+	})
+}
@@ -0,0 +1,9 @@
+package models
+
+// TimeOrdering is the order in which the backend returns the values of a metric history
+type TimeOrdering string
+
+const (
+	TimeOrderingAscending  TimeOrdering = "asc"
+	TimeOrderingDescending TimeOrdering = "desc"
+)
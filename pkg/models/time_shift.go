@@ -0,0 +1,82 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// PeriodLabel is the label which distinguishes the series of a time shifted or compared period
+	PeriodLabel    = "period"
+	PeriodCurrent  = "current"
+	PeriodPrevious = "previous"
+)
+
+// ParseDuration parses a (signed) duration like -7d, 1w or -1h30m. In addition to the units of time.ParseDuration
+// it supports days (d) and weeks (w); these units cannot be combined with other units.
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	default:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return 0, errors.Errorf("invalid duration %q", s)
+		}
+		return d, nil
+	}
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil {
+		return 0, errors.Errorf("invalid duration %q", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// Shift is a (signed) shift of a time range. Days and weeks are calendar days, which are 23 or 25 hours long on a
+// daylight saving time change, so a shift of -7d moves a time to the same wall clock time of the week before.
+type Shift struct {
+	Days     int
+	Duration time.Duration
+}
+
+// ParseShift parses a shift like ParseDuration; days (d) and weeks (w) are parsed as calendar days
+func ParseShift(s string) (Shift, error) {
+	d, err := ParseDuration(s)
+	if err != nil {
+		return Shift{}, err
+	}
+	if s = strings.TrimSpace(s); strings.HasSuffix(s, "d") || strings.HasSuffix(s, "w") {
+		return Shift{Days: int(d / (24 * time.Hour))}, nil
+	}
+	return Shift{Duration: d}, nil
+}
+
+// IsZero returns whether the shift does not move a time
+func (s Shift) IsZero() bool {
+	return s.Days == 0 && s.Duration == 0
+}
+
+// Plus returns the combination of both shifts
+func (s Shift) Plus(o Shift) Shift {
+	return Shift{Days: s.Days + o.Days, Duration: s.Duration + o.Duration}
+}
+
+// Apply returns the shifted time; the days are added in the time zone loc
+func (s Shift) Apply(t time.Time, loc *time.Location) time.Time {
+	return t.In(loc).AddDate(0, 0, s.Days).Add(s.Duration).In(t.Location())
+}
+
+// Revert returns the time before it was shifted by Apply
+func (s Shift) Revert(t time.Time, loc *time.Location) time.Time {
+	return t.In(loc).Add(-s.Duration).AddDate(0, 0, -s.Days).In(t.Location())
+}
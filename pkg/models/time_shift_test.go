@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "", want: 0},
+		{input: "-7d", want: -7 * 24 * time.Hour},
+		{input: "2w", want: 14 * 24 * time.Hour},
+		{input: "-1h30m", want: -90 * time.Minute},
+		{input: "1d2h", wantErr: true},
+		{input: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseDuration(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestShift(t *testing.T) {
+	shift, err := ParseShift("-7d")
+	assert.NoError(t, err)
+	assert.Equal(t, Shift{Days: -7}, shift)
+	shift, err = ParseShift("-1h30m")
+	assert.NoError(t, err)
+	assert.Equal(t, Shift{Duration: -90 * time.Minute}, shift)
+	_, err = ParseShift("yesterday")
+	assert.Error(t, err)
+
+	ams, _ := time.LoadLocation("Europe/Amsterdam")
+	sunday := time.Date(2023, 10, 29, 12, 0, 0, 0, ams)
+	weekAgo := Shift{Days: -7}.Apply(sunday.UTC(), ams)
+	assert.Equal(t, time.Date(2023, 10, 22, 12, 0, 0, 0, ams).UTC(), weekAgo, "a week before the end of daylight saving time is 169 hours")
+	assert.Equal(t, sunday.UTC(), Shift{Days: -7}.Revert(weekAgo, ams))
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TransformationType is a post-processing function which is applied to the numeric fields of a query result
+type TransformationType string
+
+const (
+	// TransformationRate is the per-second rate of a counter; a decreasing value is handled as a counter reset
+	TransformationRate TransformationType = "rate"
+	// TransformationDelta is the difference with the previous value
+	TransformationDelta TransformationType = "delta"
+	// TransformationMovingAverage is the average of the values in a trailing window
+	TransformationMovingAverage TransformationType = "movingAverage"
+	// TransformationMovingMedian is the median of the values in a trailing window
+	TransformationMovingMedian TransformationType = "movingMedian"
+	// TransformationCumulativeSum is the running total of the values
+	TransformationCumulativeSum TransformationType = "cumulativeSum"
+	// TransformationScale multiplies the values by a factor and adds an offset
+	TransformationScale TransformationType = "scale"
+)
+
+type Transformation struct {
+	Type TransformationType `json:"type"`
+	// Window is the trailing window of a moving function: a number of points (e.g. 5) or a duration (e.g. 15m)
+	Window string `json:"window,omitempty"`
+	// Factor is the multiplier of the scale function; the default is 1
+	Factor *float64 `json:"factor,omitempty"`
+	// Offset is the value which is added by the scale function
+	Offset float64 `json:"offset,omitempty"`
+}
+
+// IsValid returns whether the transformation type is known
+func (t TransformationType) IsValid() bool {
+	switch t {
+	case TransformationRate, TransformationDelta, TransformationMovingAverage, TransformationMovingMedian,
+		TransformationCumulativeSum, TransformationScale:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasWindow returns whether the transformation is a moving function over a trailing window
+func (t TransformationType) HasWindow() bool {
+	return t == TransformationMovingAverage || t == TransformationMovingMedian
+}
+
+// Window is the trailing window of a moving function: either a number of points or a duration
+type Window struct {
+	Points   int
+	Duration time.Duration
+}
+
+func (w Window) String() string {
+	if w.Points > 0 {
+		return fmt.Sprintf("%d points", w.Points)
+	}
+	return w.Duration.String()
+}
+
+// ParseWindow parses the window of a moving function, e.g. 5 or 15m
+func ParseWindow(s string) (Window, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if n < 1 {
+			return Window{}, errors.Errorf("invalid window %q: the number of points must be positive", s)
+		}
+		return Window{Points: n}, nil
+	}
+	d, err := ParseDuration(s)
+	if err != nil || d <= 0 {
+		return Window{}, errors.Errorf("invalid window %q: expected a number of points or a duration", s)
+	}
+	return Window{Duration: d}, nil
+}
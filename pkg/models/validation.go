@@ -0,0 +1,244 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/expression"
+	"github.com/samber/lo"
+)
+
+// FieldError is the error of an invalid field of a query
+type FieldError struct {
+	// Field is the path of the field in the query JSON, e.g. dimensions[1].key
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is the error of an invalid query
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) Error() string {
+	messages := lo.Map(e.Errors, func(fe FieldError, _ int) string { return fe.Field + ": " + fe.Message })
+	return "invalid query: " + strings.Join(messages, "; ")
+}
+
+// hasTimeRange returns whether the time range of the query is known; it is not known when a query is validated in the
+// query editor
+func (q MetricBaseQuery) hasTimeRange() bool {
+	return !q.TimeRange.From.IsZero() || !q.TimeRange.To.IsZero()
+}
+
+// Validate returns the errors of the invalid fields of the query
+func (q MetricBaseQuery) Validate() []FieldError {
+	var res []FieldError
+	// the metrics of an expression are added to the query when it is executed
+	if len(q.Metrics) == 0 && strings.TrimSpace(q.Expression) == "" {
+		res = append(res, FieldError{Field: "metrics", Message: "at least one metric is required"})
+	}
+	for i, m := range q.Metrics {
+		if strings.TrimSpace(m.MetricId) == "" {
+			res = append(res, FieldError{Field: fmt.Sprintf("metrics[%d].metricId", i), Message: "the metric id is required"})
+		}
+	}
+
+	keys := map[string]bool{}
+	for i, d := range q.Dimensions {
+		field := fmt.Sprintf("dimensions[%d]", i)
+		switch {
+		case strings.TrimSpace(d.Key) == "":
+			res = append(res, FieldError{Field: field + ".key", Message: "the dimension key is required"})
+		case keys[d.Key]:
+			res = append(res, FieldError{Field: field + ".key", Message: fmt.Sprintf("duplicate dimension key %q", d.Key)})
+		}
+		keys[d.Key] = true
+		if _, err := d.Matcher(); err != nil {
+			res = append(res, FieldError{Field: field + ".operator", Message: err.Error()})
+		}
+	}
+
+	if q.hasTimeRange() && !q.TimeRange.From.Before(q.TimeRange.To) {
+		res = append(res, FieldError{Field: "timeRange", Message: "the start of the time range should be before the end"})
+	}
+	if q.Interval < 0 {
+		res = append(res, FieldError{Field: "interval", Message: "the interval should not be negative"})
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		res = append(res, FieldError{Field: "timeZone", Message: fmt.Sprintf("unknown time zone %q", q.TimeZone)})
+	}
+	if !q.NamingStrategy.IsValid() {
+		res = append(res, FieldError{Field: "namingStrategy", Message: fmt.Sprintf("unknown naming strategy %q", q.NamingStrategy)})
+	}
+	if !q.Downsampling.IsValid() {
+		res = append(res, FieldError{Field: "downsampling", Message: fmt.Sprintf("unknown downsampling algorithm %q", q.Downsampling)})
+	}
+	if _, err := ParseShift(q.TimeShift); err != nil {
+		res = append(res, FieldError{Field: "timeShift", Message: err.Error()})
+	}
+	if _, err := ParseShift(q.CompareOffset); err != nil {
+		res = append(res, FieldError{Field: "compareOffset", Message: err.Error()})
+	}
+	res = append(res, q.validateExpression()...)
+	res = append(res, q.validateTransformations()...)
+	if q.GroupBy != nil && !q.GroupBy.Aggregation.IsValid() {
+		res = append(res, FieldError{Field: "groupBy.aggregation", Message: fmt.Sprintf("unknown aggregation %q", q.GroupBy.Aggregation)})
+	}
+	if q.Ranking != nil {
+		if !q.Ranking.Order.IsValid() {
+			res = append(res, FieldError{Field: "ranking.order", Message: fmt.Sprintf("unknown ranking order %q", q.Ranking.Order)})
+		}
+		if q.Ranking.Limit <= 0 {
+			res = append(res, FieldError{Field: "ranking.limit", Message: "the ranking limit should be greater than 0"})
+		}
+		if !q.Ranking.Reducer.IsValid() {
+			res = append(res, FieldError{Field: "ranking.reducer", Message: fmt.Sprintf("unknown reducer %q", q.Ranking.Reducer)})
+		}
+	}
+	return res
+}
+
+// validateExpression returns the errors of the expression of the query and its join tolerance
+func (q MetricBaseQuery) validateExpression() []FieldError {
+	if strings.TrimSpace(q.Expression) == "" {
+		return nil
+	}
+	var res []FieldError
+	expr, err := expression.Parse(q.Expression)
+	switch {
+	case err != nil:
+		res = append(res, FieldError{Field: "expression", Message: err.Error()})
+	case len(expr.Metrics()) == 0:
+		res = append(res, FieldError{Field: "expression", Message: "the expression does not reference a metric"})
+	}
+	if tolerance, err := ParseDuration(q.JoinTolerance); err != nil || tolerance < 0 {
+		res = append(res, FieldError{Field: "joinTolerance", Message: fmt.Sprintf("invalid duration %q", q.JoinTolerance)})
+	}
+	return res
+}
+
+// validateTransformations returns the errors of the types and windows of the transformations of the query
+func (q MetricBaseQuery) validateTransformations() []FieldError {
+	var res []FieldError
+	for i, t := range q.Transformations {
+		field := fmt.Sprintf("transformations[%d]", i)
+		if !t.Type.IsValid() {
+			res = append(res, FieldError{Field: field + ".type", Message: fmt.Sprintf("unknown transformation %q", t.Type)})
+			continue
+		}
+		if t.Type.HasWindow() {
+			if _, err := ParseWindow(t.Window); err != nil {
+				res = append(res, FieldError{Field: field + ".window", Message: err.Error()})
+			}
+		}
+	}
+	return res
+}
+
+// Validate returns the errors of the invalid fields of the query
+func (q MetricAggregateQuery) Validate() []FieldError {
+	res := q.MetricBaseQuery.Validate()
+	if q.Interval == 0 && q.CalendarInterval == CalendarIntervalNone && q.hasTimeRange() {
+		res = append(res, FieldError{Field: "interval", Message: "an interval or a calendar interval is required"})
+	}
+	if !q.CalendarInterval.IsValid() {
+		res = append(res, FieldError{Field: "calendarInterval", Message: fmt.Sprintf("unknown calendar interval %q", q.CalendarInterval)})
+	}
+	if !q.FillMode.IsValid() {
+		res = append(res, FieldError{Field: "fillMode", Message: fmt.Sprintf("unknown fill mode %q", q.FillMode)})
+	}
+	return res
+}
+
+// ValidateOptions returns the errors of the query options which do not match their definitions
+func ValidateOptions(options map[string]OptionValue, definitions Options) []FieldError {
+	var res []FieldError
+	for _, def := range definitions {
+		if !def.DependenciesMet(options) {
+			continue
+		}
+		field := fmt.Sprintf("queryOptions.%s", def.ID)
+		value, selected := options[def.ID]
+		if !selected || value.IsEmpty() {
+			if def.Required {
+				res = append(res, FieldError{Field: field, Message: fmt.Sprintf("option %s is required", def.Label)})
+			}
+			continue
+		}
+		if msg := def.validateValue(value); msg != "" {
+			res = append(res, FieldError{Field: field, Message: msg})
+		}
+	}
+	return res
+}
+
+// validateValue returns a message if the value does not match the type or the constraints of the option
+func (o Option) validateValue(value OptionValue) string {
+	switch o.Type {
+	case OptionTypeEnum, OptionTypeMultiEnum:
+		if o.Type == OptionTypeEnum && len(value.Values) > 1 {
+			return fmt.Sprintf("option %s has a single value", o.Label)
+		}
+		for _, v := range value.SelectedValues() {
+			if !lo.ContainsBy(o.EnumValues, func(e EnumValue) bool { return e.ID == v }) {
+				return fmt.Sprintf("%q is not a valid value of option %s", v, o.Label)
+			}
+		}
+	case OptionTypeBoolean:
+		if value.Value != "true" && value.Value != "false" {
+			return fmt.Sprintf("option %s should be true or false", o.Label)
+		}
+	case OptionTypeNumber:
+		n, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return fmt.Sprintf("option %s should be a number", o.Label)
+		}
+		return o.validateRange(n, "")
+	case OptionTypeText:
+		if o.Pattern != "" {
+			re, err := regexp.Compile("^(?:" + o.Pattern + ")$")
+			if err != nil {
+				return fmt.Sprintf("option %s has an invalid pattern: %s", o.Label, err)
+			}
+			if !re.MatchString(value.Value) {
+				return fmt.Sprintf("option %s should match %s", o.Label, o.Pattern)
+			}
+		}
+		return o.validateRange(float64(utf8.RuneCountInString(value.Value)), "the length of ")
+	case OptionTypeDuration:
+		if _, err := ParseDuration(value.Value); err != nil {
+			return fmt.Sprintf("option %s should be a duration, e.g. 5m", o.Label)
+		}
+	case OptionTypeDateRange:
+		if value.DateRange == nil {
+			return fmt.Sprintf("option %s should be a date range", o.Label)
+		}
+		if !value.DateRange.From.Before(value.DateRange.To) {
+			return fmt.Sprintf("the start of option %s should be before its end", o.Label)
+		}
+	}
+	return ""
+}
+
+// validateRange returns a message if n is not between the min. and max. of the option, or not a multiple of its step
+func (o Option) validateRange(n float64, subject string) string {
+	if o.Min != nil && n < *o.Min {
+		return fmt.Sprintf("%soption %s should be at least %g", subject, o.Label, *o.Min)
+	}
+	if o.Max != nil && n > *o.Max {
+		return fmt.Sprintf("%soption %s should be at most %g", subject, o.Label, *o.Max)
+	}
+	if o.Step > 0 {
+		steps := (n - lo.FromPtr(o.Min)) / o.Step
+		if math.Abs(steps-math.Round(steps)) > 1e-9 {
+			return fmt.Sprintf("%soption %s should be a multiple of %g", subject, o.Label, o.Step)
+		}
+	}
+	return ""
+}
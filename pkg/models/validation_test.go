@@ -0,0 +1,151 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func fields(errs []FieldError) []string {
+	return lo.Map(errs, func(fe FieldError, _ int) string { return fe.Field })
+}
+
+func TestMetricBaseQuery_Validate(t *testing.T) {
+	now := time.Now()
+	valid := MetricBaseQuery{
+		Metrics:    []Metric{{MetricId: "temperature"}},
+		Dimensions: []Dimension{{Key: "machine", Value: "m1"}},
+		TimeRange:  backend.TimeRange{From: now.Add(-time.Hour), To: now},
+	}
+	assert.Empty(t, valid.Validate())
+
+	invalid := MetricBaseQuery{
+		Metrics: []Metric{{MetricId: " "}},
+		Dimensions: []Dimension{
+			{Key: "machine", Value: "m1"},
+			{Key: "", Value: "m2"},
+			{Key: "machine", Value: "m3"},
+			{Key: "line", Value: "(", Operator: DimensionOperatorRegex},
+		},
+		TimeRange:      backend.TimeRange{From: now, To: now.Add(-time.Hour)},
+		Interval:       -time.Second,
+		NamingStrategy: "random",
+	}
+	assert.Equal(t, []string{
+		"metrics[0].metricId",
+		"dimensions[1].key",
+		"dimensions[2].key",
+		"dimensions[3].operator",
+		"timeRange",
+		"interval",
+		"namingStrategy",
+	}, fields(invalid.Validate()))
+
+	assert.Equal(t, []string{"metrics"}, fields(MetricBaseQuery{}.Validate()))
+	assert.Empty(t, MetricBaseQuery{Expression: "good / total"}.Validate())
+
+	postProcessing := valid
+	postProcessing.Expression = "100"
+	postProcessing.JoinTolerance = "soon"
+	postProcessing.Downsampling = "median"
+	postProcessing.TimeShift = "yesterday"
+	postProcessing.Transformations = []Transformation{{Type: TransformationRate}, {Type: "integral"}, {Type: TransformationMovingMedian}}
+	postProcessing.GroupBy = &GroupBy{Keys: []string{"machine"}, Aggregation: "median"}
+	postProcessing.Ranking = &Ranking{Order: "first", Reducer: "min"}
+	assert.Equal(t, []string{
+		"downsampling",
+		"timeShift",
+		"expression",
+		"joinTolerance",
+		"transformations[1].type",
+		"transformations[2].window",
+		"groupBy.aggregation",
+		"ranking.order",
+		"ranking.limit",
+		"ranking.reducer",
+	}, fields(postProcessing.Validate()))
+}
+
+func TestMetricAggregateQuery_Validate(t *testing.T) {
+	now := time.Now()
+	query := MetricAggregateQuery{MetricBaseQuery: MetricBaseQuery{
+		Metrics:   []Metric{{MetricId: "temperature"}},
+		TimeRange: backend.TimeRange{From: now.Add(-time.Hour), To: now},
+	}}
+	assert.Equal(t, []string{"interval"}, fields(query.Validate()))
+
+	query.CalendarInterval = CalendarIntervalDay
+	query.TimeZone = "Mars/Olympus_Mons"
+	assert.Equal(t, []string{"timeZone"}, fields(query.Validate()))
+
+	query.TimeZone = ""
+	query.CalendarInterval = "fortnight"
+	query.FillMode = "spline"
+	assert.Equal(t, []string{"calendarInterval", "fillMode"}, fields(query.Validate()))
+}
+
+func TestValidateOptions(t *testing.T) {
+	definitions := Options{
+		{ID: "0", Label: "Aggregate", Type: "Enum", Required: true, EnumValues: []EnumValue{{ID: "avg"}, {ID: "max"}}},
+		{ID: "1", Label: "Interpolate", Type: "Boolean"},
+		{ID: "2", Label: "Resolution", Type: "Enum", EnumValues: []EnumValue{{ID: "high"}}},
+	}
+
+	assert.Empty(t, ValidateOptions(map[string]OptionValue{"0": {Value: "max"}, "1": {Value: "true"}}, definitions))
+	assert.Equal(t, []FieldError{
+		{Field: "queryOptions.0", Message: "option Aggregate is required"},
+	}, ValidateOptions(map[string]OptionValue{"0": {Value: ""}}, definitions))
+	assert.Equal(t, []FieldError{
+		{Field: "queryOptions.0", Message: `"median" is not a valid value of option Aggregate`},
+		{Field: "queryOptions.1", Message: "option Interpolate should be true or false"},
+	}, ValidateOptions(map[string]OptionValue{"0": {Value: "median"}, "1": {Value: "yes"}}, definitions))
+}
+
+func TestValidateOptions_Types(t *testing.T) {
+	definitions := Options{
+		{ID: "percentile", Label: "Percentile", Type: OptionTypeNumber, Min: lo.ToPtr(0.0), Max: lo.ToPtr(100.0), Step: 0.5},
+		{ID: "tag", Label: "Tag", Type: OptionTypeText, Pattern: "[a-z]+", Max: lo.ToPtr(5.0)},
+		{ID: "window", Label: "Window", Type: OptionTypeDuration},
+		{ID: "period", Label: "Period", Type: OptionTypeDateRange},
+		{ID: "shifts", Label: "Shifts", Type: OptionTypeMultiEnum, EnumValues: []EnumValue{{ID: "a"}, {ID: "b"}}},
+		{ID: "smoothing", Label: "Smoothing", Type: OptionTypeNumber, Required: true, DependsOn: []OptionDependency{{OptionID: "shifts", Values: []string{"b"}}}},
+	}
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, ValidateOptions(map[string]OptionValue{
+		"percentile": {Value: "99.5"},
+		"tag":        {Value: "line"},
+		"window":     {Value: "1d"},
+		"period":     {DateRange: &DateRange{From: from, To: from.Add(time.Hour)}},
+		"shifts":     {Values: []string{"a"}},
+	}, definitions))
+
+	tests := []struct {
+		name    string
+		options map[string]OptionValue
+		want    string
+	}{
+		{name: "not a number", options: map[string]OptionValue{"percentile": {Value: "high"}}, want: "option Percentile should be a number"},
+		{name: "below min", options: map[string]OptionValue{"percentile": {Value: "-1"}}, want: "option Percentile should be at least 0"},
+		{name: "above max", options: map[string]OptionValue{"percentile": {Value: "101"}}, want: "option Percentile should be at most 100"},
+		{name: "not a step", options: map[string]OptionValue{"percentile": {Value: "99.9"}}, want: "option Percentile should be a multiple of 0.5"},
+		{name: "pattern", options: map[string]OptionValue{"tag": {Value: "Line"}}, want: "option Tag should match [a-z]+"},
+		{name: "length", options: map[string]OptionValue{"tag": {Value: "machine"}}, want: "the length of option Tag should be at most 5"},
+		{name: "duration", options: map[string]OptionValue{"window": {Value: "5 minutes"}}, want: "option Window should be a duration, e.g. 5m"},
+		{name: "no date range", options: map[string]OptionValue{"period": {Value: "last week"}}, want: "option Period should be a date range"},
+		{name: "empty date range", options: map[string]OptionValue{"period": {DateRange: &DateRange{From: from, To: from}}}, want: "the start of option Period should be before its end"},
+		{name: "multi enum", options: map[string]OptionValue{"shifts": {Values: []string{"a", "c"}}}, want: `"c" is not a valid value of option Shifts`},
+		{name: "dependency met", options: map[string]OptionValue{"shifts": {Values: []string{"a", "b"}}}, want: "option Smoothing is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateOptions(tt.options, definitions)
+			if assert.Len(t, errs, 1) {
+				assert.Equal(t, tt.want, errs[0].Message)
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/pkg/errors"
 )
 
@@ -46,11 +47,39 @@ func DataResponseErrorUnmarshal(err error) backend.DataResponse {
 }
 
 func DataResponseErrorRequestFailed(err error) backend.DataResponse {
+	var validationErr *models.ValidationError
+	if errors.As(err, &validationErr) {
+		return dataResponseInvalidQuery(err, validationErr)
+	}
+	return backend.DataResponse{
+		Error:  err,
+		Status: backend.Status(httpStatusFromCode(status.Code(err))),
+	}
+}
+
+// dataResponseInvalidQuery returns the response of an invalid query. The field errors are returned in the custom meta
+// of a frame, in the same format as the validate endpoint, and as a notice per field. An invalid query is an error of
+// its author, so the error source is downstream.
+func dataResponseInvalidQuery(err error, validationErr *models.ValidationError) backend.DataResponse {
+	notices := make([]data.Notice, len(validationErr.Errors))
+	for i, fe := range validationErr.Errors {
+		notices[i] = data.Notice{Severity: data.NoticeSeverityError, Text: fe.Field + ": " + fe.Message}
+	}
 	return backend.DataResponse{
-		Error: err,
+		Frames:      data.Frames{{Meta: &data.FrameMeta{Custom: validationErr, Notices: notices}}},
+		Error:       err,
+		Status:      backend.Status(httpStatusFromCode(codes.InvalidArgument)),
+		ErrorSource: backend.ErrorSourceDownstream,
 	}
 }
 
+// DataResponsePartialResult returns the frames of a partially failed request; the response is still treated as failed.
+func DataResponsePartialResult(frames data.Frames, err error) backend.DataResponse {
+	res := DataResponseErrorRequestFailed(err)
+	res.Frames = frames
+	return res
+}
+
 // GetQueryHandlers creates the QueryTypeMux type for handling queries
 func (d *Datasource) registerQueryHandlers() {
 	mux := datasource.NewQueryTypeMux()
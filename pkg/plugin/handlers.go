@@ -2,9 +2,15 @@ package plugin
 
 import (
 	"context"
+	"fmt"
 
 	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models/querylang"
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func processQueries(ctx context.Context, req *backend.QueryDataRequest, handler QueryHandlerFunc) *backend.QueryDataResponse {
@@ -23,6 +29,136 @@ func processQueries(ctx context.Context, req *backend.QueryDataRequest, handler
 	}
 }
 
+// compileRawQuery replaces the structured query by the compiled text query, if the query has one
+func compileRawQuery(query *models.MetricBaseQuery) error {
+	if query.RawQuery == "" {
+		return nil
+	}
+	compiled, err := querylang.Compile(query.RawQuery, *query)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	*query = compiled
+	return nil
+}
+
+// withMacros lists the macros which are expanded in a query in the executed query string of its frames
+func withMacros(frames data.Frames, macros models.Macros) data.Frames {
+	if len(macros) == 0 {
+		return frames
+	}
+	for _, frame := range frames {
+		if frame.Meta == nil {
+			frame.Meta = &data.FrameMeta{}
+		}
+		executed := "macros: " + macros.String()
+		if frame.Meta.ExecutedQueryString != "" {
+			executed = executed + "\n" + frame.Meta.ExecutedQueryString
+		}
+		frame.Meta.ExecutedQueryString = executed
+	}
+	return frames
+}
+
+// maxOptionDefaultRounds limits the number of times the option definitions are resolved while applying defaults; the
+// definitions of a backend may depend on the selected options, so a default can introduce new options
+const maxOptionDefaultRounds = 5
+
+// validateQuery applies the default values and the types of the options of the query. It returns a
+// *models.ValidationError if the query has invalid fields or if its options do not match the option definitions of
+// the backend. Queries of the query editor, alert rules and provisioned dashboards are treated the same.
+// If the option definitions cannot be retrieved, the query is executed with its own options; the returned notice
+// warns that the options are not validated.
+func (s *Datasource) validateQuery(ctx context.Context, query *models.MetricBaseQuery, fieldErrors []models.FieldError) (*data.Notice, error) {
+	var (
+		definitions *models.GetQueryOptionsResponse
+		warning     *data.Notice
+	)
+	selected := query.Options
+	for i := 0; i < maxOptionDefaultRounds; i++ {
+		res, err := s.backendAPI.GetQueryOptions(ctx, models.GetQueryOptionsRequest{
+			QueryType:       query.QueryType,
+			SelectedOptions: models.SelectedOptions(query.Options),
+		})
+		// a backend which does not support query options has nothing to validate
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			log.DefaultLogger.Warn("could not get the query options; the query is executed without defaults", "error", err.Error())
+			warning = &data.Notice{
+				Severity: data.NoticeSeverityWarning,
+				Text:     fmt.Sprintf("The query options are not validated and have no defaults: %s", status.Convert(err).Message()),
+			}
+			query.Options, res = selected, nil
+		}
+		definitions = res
+		if definitions == nil {
+			break
+		}
+		options, applied := models.ApplyOptionDefaults(query.Options, definitions.Options)
+		if !applied {
+			break
+		}
+		query.Options = options
+	}
+	if definitions != nil {
+		fieldErrors = append(fieldErrors, models.ValidateOptions(query.Options, definitions.Options)...)
+		query.Options = models.ApplyOptionTypes(query.Options, definitions.Options)
+	}
+	if len(fieldErrors) > 0 {
+		return warning, &models.ValidationError{Errors: fieldErrors}
+	}
+	return warning, nil
+}
+
+// withNotice adds a notice to the first frame; a frame is added if there are no frames
+func withNotice(frames data.Frames, notice *data.Notice) data.Frames {
+	if notice == nil {
+		return frames
+	}
+	if len(frames) == 0 {
+		frames = data.Frames{data.NewFrame("")}
+	}
+	if frames[0].Meta == nil {
+		frames[0].Meta = &data.FrameMeta{}
+	}
+	frames[0].Meta.Notices = append(frames[0].Meta.Notices, *notice)
+	return frames
+}
+
+// validateDataQuery unmarshals, compiles and validates a query of any query type
+func (s *Datasource) validateDataQuery(ctx context.Context, q backend.DataQuery) error {
+	var (
+		query       *models.MetricBaseQuery
+		fieldErrors func() []models.FieldError
+	)
+	switch q.QueryType {
+	case models.QueryMetricValue:
+		valueQuery, err := models.UnmarshalToMetricValueQuery(&q)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		query, fieldErrors = &valueQuery.MetricBaseQuery, valueQuery.Validate
+	case models.QueryMetricHistory:
+		historyQuery, err := models.UnmarshalToMetricHistoryQuery(&q)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		query, fieldErrors = &historyQuery.MetricBaseQuery, historyQuery.Validate
+	case models.QueryMetricAggregate:
+		aggregateQuery, err := models.UnmarshalToMetricAggregateQuery(&q)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		query, fieldErrors = &aggregateQuery.MetricBaseQuery, aggregateQuery.Validate
+	default:
+		return &models.ValidationError{Errors: []models.FieldError{{Field: "queryType", Message: fmt.Sprintf("unknown query type %q", q.QueryType)}}}
+	}
+	if err := compileRawQuery(query); err != nil {
+		return &models.ValidationError{Errors: []models.FieldError{{Field: "rawQuery", Message: status.Convert(err).Message()}}}
+	}
+	_, err := s.validateQuery(ctx, query, fieldErrors())
+	return err
+}
+
 func (s *Datasource) HandleGetMetricValueQuery(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
 	return processQueries(ctx, req, s.handleGetMetricValueQuery), nil
 }
@@ -32,8 +168,17 @@ func (s *Datasource) handleGetMetricValueQuery(ctx context.Context, req backend.
 	if err != nil {
 		return DataResponseErrorUnmarshal(err)
 	}
+	if err := compileRawQuery(&query.MetricBaseQuery); err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
+	macros := query.ExpandMacros(models.NewMacros(req.PluginContext, q, query.TimeZone))
+	warning, err := s.validateQuery(ctx, &query.MetricBaseQuery, query.Validate())
+	if err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
 
 	frames, err := s.backendAPI.HandleGetMetricValueQuery(ctx, query)
+	frames = withNotice(withMacros(frames, macros), warning)
 	if err != nil {
 		return DataResponseErrorRequestFailed(err)
 	}
@@ -53,10 +198,19 @@ func (s *Datasource) handleGetMetricHistoryQuery(ctx context.Context, req backen
 	if err != nil {
 		return DataResponseErrorUnmarshal(err)
 	}
+	if err := compileRawQuery(&query.MetricBaseQuery); err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
+	macros := query.ExpandMacros(models.NewMacros(req.PluginContext, q, query.TimeZone))
+	warning, err := s.validateQuery(ctx, &query.MetricBaseQuery, query.Validate())
+	if err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
 
 	frames, err := s.backendAPI.HandleGetMetricHistoryQuery(ctx, query)
+	frames = withNotice(withMacros(frames, macros), warning)
 	if err != nil {
-		return DataResponseErrorRequestFailed(err)
+		return DataResponsePartialResult(frames, err)
 	}
 
 	return backend.DataResponse{
@@ -74,10 +228,19 @@ func (s *Datasource) handleGetMetricAggregateQuery(ctx context.Context, req back
 	if err != nil {
 		return DataResponseErrorUnmarshal(err)
 	}
+	if err := compileRawQuery(&query.MetricBaseQuery); err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
+	macros := query.ExpandMacros(models.NewMacros(req.PluginContext, q, query.TimeZone))
+	warning, err := s.validateQuery(ctx, &query.MetricBaseQuery, query.Validate())
+	if err != nil {
+		return DataResponseErrorRequestFailed(err)
+	}
 
 	frames, err := s.backendAPI.HandleGetMetricAggregateQuery(ctx, query)
+	frames = withNotice(withMacros(frames, macros), warning)
 	if err != nil {
-		return DataResponseErrorRequestFailed(err)
+		return DataResponsePartialResult(frames, err)
 	}
 
 	return backend.DataResponse{
@@ -0,0 +1,166 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"bitbucket.org/innius/grafana-simple-grpc-datasource/pkg/models"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// optionDefaultsStub is a backend with a required option and an option whose definitions depend on the first one
+type optionDefaultsStub struct {
+	backendAPIStub
+	query *models.MetricValueQuery
+}
+
+func (stub *optionDefaultsStub) GetQueryOptions(ctx context.Context, input models.GetQueryOptionsRequest) (*models.GetQueryOptionsResponse, error) {
+	options := models.Options{
+		{ID: "unit", Label: "Unit", Type: models.OptionTypeEnum, Required: true, EnumValues: []models.EnumValue{{ID: "celsius", Label: "Celsius", Default: true}, {ID: "kelvin"}}},
+		{ID: "mode", Label: "Mode", Type: models.OptionTypeEnum, Required: true, EnumValues: []models.EnumValue{{ID: "raw"}, {ID: "smooth"}}},
+	}
+	if input.SelectedOptions["unit"] == "celsius" {
+		options = append(options, models.Option{ID: "precision", Label: "Precision", Type: models.OptionTypeEnum, EnumValues: []models.EnumValue{{ID: "1", Label: "1 decimal", Default: true}}})
+	}
+	return &models.GetQueryOptionsResponse{Options: options}, nil
+}
+
+func (stub *optionDefaultsStub) HandleGetMetricValueQuery(ctx context.Context, query *models.MetricValueQuery) (data.Frames, error) {
+	stub.query = query
+	return data.Frames{}, nil
+}
+
+func TestHandleQuery_OptionDefaults(t *testing.T) {
+	stub := &optionDefaultsStub{}
+	inst, err := newDatasourceWithBackendAPI(stub)
+	assert.NoError(t, err)
+	ds := inst.(*Datasource)
+
+	t.Run("missing options get the defaults of the backend", func(t *testing.T) {
+		res := ds.handleGetMetricValueQuery(context.TODO(), backend.QueryDataRequest{}, backend.DataQuery{
+			QueryType: models.QueryMetricValue,
+			JSON:      []byte(`{"metrics": [{"metricId": "temperature"}], "queryOptions": {"mode": {"value": "raw"}}}`),
+		})
+		assert.NoError(t, res.Error)
+		if assert.NotNil(t, stub.query) {
+			assert.Equal(t, map[string]models.OptionValue{
+				"unit":      {Value: "celsius", Label: "Celsius", Type: models.OptionTypeEnum},
+				"mode":      {Value: "raw", Type: models.OptionTypeEnum},
+				"precision": {Value: "1", Label: "1 decimal", Type: models.OptionTypeEnum},
+			}, stub.query.Options)
+		}
+	})
+
+	t.Run("a query without a required option is rejected", func(t *testing.T) {
+		res := ds.handleGetMetricValueQuery(context.TODO(), backend.QueryDataRequest{}, backend.DataQuery{
+			QueryType: models.QueryMetricValue,
+			JSON:      []byte(`{"metrics": [{"metricId": "temperature"}]}`),
+		})
+		assert.EqualError(t, res.Error, "invalid query: queryOptions.mode: option Mode is required")
+		assert.Equal(t, backend.ErrorSourceDownstream, res.ErrorSource)
+		if assert.Len(t, res.Frames, 1) {
+			assert.Equal(t, &models.ValidationError{Errors: []models.FieldError{
+				{Field: "queryOptions.mode", Message: "option Mode is required"},
+			}}, res.Frames[0].Meta.Custom)
+			assert.Len(t, res.Frames[0].Meta.Notices, 1)
+		}
+	})
+}
+
+// unavailableOptionsStub is a backend whose query options cannot be retrieved
+type unavailableOptionsStub struct {
+	optionDefaultsStub
+}
+
+func (stub *unavailableOptionsStub) GetQueryOptions(ctx context.Context, input models.GetQueryOptionsRequest) (*models.GetQueryOptionsResponse, error) {
+	return nil, status.Error(codes.Unavailable, "connection refused")
+}
+
+func TestHandleQuery_QueryOptionsUnavailable(t *testing.T) {
+	stub := &unavailableOptionsStub{}
+	inst, err := newDatasourceWithBackendAPI(stub)
+	assert.NoError(t, err)
+	ds := inst.(*Datasource)
+
+	res := ds.handleGetMetricValueQuery(context.TODO(), backend.QueryDataRequest{}, backend.DataQuery{
+		QueryType: models.QueryMetricValue,
+		JSON:      []byte(`{"metrics": [{"metricId": "temperature"}], "queryOptions": {"mode": {"value": "raw"}}}`),
+	})
+	assert.NoError(t, res.Error)
+	if assert.NotNil(t, stub.query) {
+		assert.Equal(t, map[string]models.OptionValue{"mode": {Value: "raw"}}, stub.query.Options, "the query is executed without defaults")
+	}
+	if assert.Len(t, res.Frames, 1) {
+		assert.Equal(t, []data.Notice{{
+			Severity: data.NoticeSeverityWarning,
+			Text:     "The query options are not validated and have no defaults: connection refused",
+		}}, res.Frames[0].Meta.Notices)
+	}
+}
+
+// macrosStub returns a frame with the dimension value of the query
+type macrosStub struct {
+	optionDefaultsStub
+}
+
+func (stub *macrosStub) HandleGetMetricValueQuery(ctx context.Context, query *models.MetricValueQuery) (data.Frames, error) {
+	stub.query = query
+	return data.Frames{data.NewFrame(query.Dimensions[0].Value)}, nil
+}
+
+func TestHandleQuery_Macros(t *testing.T) {
+	stub := &macrosStub{}
+	inst, err := newDatasourceWithBackendAPI(stub)
+	assert.NoError(t, err)
+	ds := inst.(*Datasource)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	res := ds.handleGetMetricValueQuery(context.TODO(), backend.QueryDataRequest{PluginContext: backend.PluginContext{OrgID: 3}}, backend.DataQuery{
+		QueryType: models.QueryMetricValue,
+		TimeRange: backend.TimeRange{From: from, To: from.Add(time.Hour)},
+		Interval:  time.Minute,
+		JSON:      []byte(`{"metrics": [{"metricId": "temperature"}], "dimensions": [{"key": "window", "value": "$__interval"}], "displayName": "org $__org_id", "queryOptions": {"mode": {"value": "raw"}}}`),
+	})
+	assert.NoError(t, res.Error)
+	assert.Equal(t, "org 3", stub.query.DisplayName)
+	if assert.Len(t, res.Frames, 1) {
+		assert.Equal(t, "1m", res.Frames[0].Name)
+		assert.Equal(t, "macros: $__interval=1m, $__org_id=3", res.Frames[0].Meta.ExecutedQueryString)
+	}
+}
+
+func (stub *macrosStub) HandleGetMetricHistoryQuery(ctx context.Context, query *models.MetricHistoryQuery) (data.Frames, error) {
+	return data.Frames{data.NewFrame(query.Dimensions[0].Value)}, nil
+}
+
+func TestHandleQuery_TimeZoneMacro(t *testing.T) {
+	stub := &macrosStub{}
+	inst, err := newDatasourceWithBackendAPI(stub)
+	assert.NoError(t, err)
+	ds := inst.(*Datasource)
+
+	q := backend.DataQuery{
+		TimeRange: backend.TimeRange{From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		Interval:  time.Minute,
+		JSON:      []byte(`{"metrics": [{"metricId": "temperature"}], "dimensions": [{"key": "zone", "value": "$__timezone"}], "timeZone": "Europe/Amsterdam", "queryOptions": {"mode": {"value": "raw"}}}`),
+	}
+	handlers := map[string]func(context.Context, backend.QueryDataRequest, backend.DataQuery) backend.DataResponse{
+		models.QueryMetricValue:   ds.handleGetMetricValueQuery,
+		models.QueryMetricHistory: ds.handleGetMetricHistoryQuery,
+	}
+	for queryType, handle := range handlers {
+		t.Run(queryType, func(t *testing.T) {
+			q.QueryType = queryType
+			res := handle(context.TODO(), backend.QueryDataRequest{}, q)
+			assert.NoError(t, res.Error)
+			if assert.Len(t, res.Frames, 1) {
+				assert.Equal(t, "Europe/Amsterdam", res.Frames[0].Name)
+			}
+		})
+	}
+}
@@ -3,8 +3,12 @@ package plugin
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/log"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -230,7 +234,63 @@ func (s *Datasource) handleGetMetrics(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// validateRequest is the request of the validate endpoint; it is the JSON of a query
+type validateRequest struct {
+	QueryType  string `json:"queryType"`
+	IntervalMs int64  `json:"intervalMs"`
+}
+
+// validateResponse contains the errors of the invalid fields of a query; a valid query has no errors
+type validateResponse struct {
+	Errors []models.FieldError `json:"errors"`
+}
+
+func (s *Datasource) handleValidateQuery(w http.ResponseWriter, r *http.Request) {
+	logger := log.DefaultLogger.With("method", "handleValidateQuery")
+
+	if r.Body == nil {
+		http.Error(w, "request does not have a body", http.StatusBadRequest)
+		return
+	}
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var req validateRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "Failed to decode JSON", http.StatusBadRequest)
+		return
+	}
+
+	res := validateResponse{Errors: []models.FieldError{}}
+	err = s.validateDataQuery(r.Context(), backend.DataQuery{
+		QueryType: req.QueryType,
+		Interval:  time.Duration(req.IntervalMs) * time.Millisecond,
+		JSON:      body,
+	})
+	var validationErr *models.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		res.Errors = validationErr.Errors
+	case err != nil:
+		logger.Error("could not validate the query", "error", err.Error())
+		renderError(r.Context(), status.Convert(err), w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 func (a *Datasource) registerRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("/validate", a.handleValidateQuery)
 	mux.HandleFunc("/options", a.handleGetQueryOptions)
 	mux.HandleFunc("/dimensions", a.handleGetDimensionKeys)
 	mux.HandleFunc("/dimensions/values", a.handleGetDimensionValues)
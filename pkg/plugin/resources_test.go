@@ -243,6 +243,45 @@ func TestCallResource(t *testing.T) {
 			expBody:   []byte(`[]`),
 			expStatus: http.StatusOK,
 		},
+		{
+			name:      "validate a valid query",
+			method:    http.MethodPost,
+			path:      "validate",
+			body:      []byte(`{"queryType": "GetMetricHistory", "metrics": [{"metricId": "foo"}], "dimensions": [{"key": "foo", "value": "bar"}]}`),
+			expBody:   []byte(`{"errors":[]}`),
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "validate an invalid query",
+			method:    http.MethodPost,
+			path:      "validate",
+			body:      []byte(`{"queryType": "GetMetricAggregate", "intervalMs": 1000, "dimensions": [{"key": "foo", "value": "bar"}, {"key": "foo", "value": "baz"}]}`),
+			expBody:   []byte(`{"errors":[{"field":"metrics","message":"at least one metric is required"},{"field":"dimensions[1].key","message":"duplicate dimension key \"foo\""}]}`),
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "validate the post-processing of a query",
+			method:    http.MethodPost,
+			path:      "validate",
+			body:      []byte(`{"queryType": "GetMetricAggregate", "intervalMs": 1000, "metrics": [{"metricId": "foo"}], "expression": "1 + 2", "fillMode": "spline", "transformations": [{"type": "movingAverage", "window": "0"}], "ranking": {"order": "top", "limit": 3, "reducer": "median"}}`),
+			expBody:   []byte(`{"errors":[{"field":"expression","message":"the expression does not reference a metric"},{"field":"transformations[0].window","message":"invalid window \"0\": the number of points must be positive"},{"field":"ranking.reducer","message":"unknown reducer \"median\""},{"field":"fillMode","message":"unknown fill mode \"spline\""}]}`),
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "validate an invalid raw query",
+			method:    http.MethodPost,
+			path:      "validate",
+			body:      []byte(`{"queryType": "GetMetricValue", "rawQuery": "foo{"}`),
+			expBody:   []byte(`{"errors":[{"field":"rawQuery","message":"syntax error at position 5: expected a dimension key, got end of query"}]}`),
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "validate a query with an invalid payload",
+			method:    http.MethodPost,
+			path:      "validate",
+			body:      []byte(`{"queryType": "GetMetricValue", "metrics": "invalid json string"}`),
+			expStatus: http.StatusBadRequest,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			// Request by calling CallResource. This tests the httpadapter.
@@ -4,7 +4,7 @@ import { Select, AsyncMultiSelect, InlineField, Input } from '@grafana/ui';
 import { QueryEditorProps, SelectableValue, } from '@grafana/data';
 
 import { DataSource } from 'datasource';
-import { defaultQuery, Dimension, MyDataSourceOptions, MyQuery, QueryType, QueryOptionValue, QueryOptionDefinitions, OptionType, QueryOptions } from 'types';
+import { defaultQuery, Dimension, MyDataSourceOptions, MyQuery, QueryType, QueryOptionValue, QueryOptionDefinitions, OptionType, QueryOptions, SCHEMA_VERSION } from 'types';
 import { queryTypeInfos } from 'queryInfo';
 import DimensionSettings from './DimensionSettings';
 import QueryOptionsEditor from './QueryOptionsEditor';
@@ -50,6 +50,8 @@ const QueryEditor = (props: Props) => {
 
     const updateAndRunQuery = (q: MyQuery) => {
         const { onChange, onRunQuery } = props;
+        // a saved query is always of the current version, so the backend does not migrate it
+        q = { ...q, schemaVersion: SCHEMA_VERSION };
         onChange(q);
         setQuery(q);
         onRunQuery();
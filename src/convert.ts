@@ -1,11 +1,11 @@
-import { Metric, MyQuery } from './types';
+import { Metric, MyQuery, NextQuery, QueryOptions, SCHEMA_VERSION } from './types';
 
 /**
  * convert a legacy query to a valid query definition
  * @param query query which might contain a legacy metric definition using deprecated metricId, metricName fields.
  */
 export function convertQuery(query: MyQuery): MyQuery {
-  let options = query.queryOptions;
+  let options = convertOptions(query.queryOptions);
   // convert deprecated aggregateType to query options
   if (!options && query.aggregateType) {
     const { aggregateType } = query;
@@ -28,13 +28,32 @@ export function convertQuery(query: MyQuery): MyQuery {
       "0": { value: aggregateTypeEnumValue.toString(), label: aggregateType.toLowerCase() },
     };
   }
-  return {
+  const converted: NextQuery = {
     ...query,
+    schemaVersion: SCHEMA_VERSION,
     metricId: undefined,
     aggregateType: undefined,
+    // the plugin retrieves all pages of a query itself; a stored token is never used
+    nextToken: undefined,
     queryOptions: options,
     metrics: convertMetrics(query),
   };
+  return converted;
+}
+
+/**
+ * converts legacy option values, which are plain strings, to option values
+ * @param options query options which might contain legacy string values
+ */
+function convertOptions(options?: QueryOptions): QueryOptions | undefined {
+  if (!options) {
+    return options;
+  }
+  const res: QueryOptions = {};
+  for (const [key, value] of Object.entries(options)) {
+    res[key] = typeof value === 'string' ? { value } : value;
+  }
+  return res;
 }
 
 /**
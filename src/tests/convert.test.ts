@@ -1,4 +1,4 @@
-import { MyQuery, QueryType } from 'types';
+import { MyQuery, NextQuery, QueryType, SCHEMA_VERSION } from 'types';
 import { convertQuery } from '../convert';
 describe('query-conversion', () => {
   describe('a query with deprecated aggregateType', () => {
@@ -56,3 +56,23 @@ describe('query-conversion', () => {
     });
   });
 });
+
+describe('schema version', () => {
+  const legacyQuery = {
+    refId: 'foo',
+    queryType: QueryType.GetMetricAggregate,
+    metricId: 'temperature',
+    queryOptions: { '0': '2' },
+    nextToken: 'token',
+  } as unknown as MyQuery;
+  const query = convertQuery(legacyQuery) as NextQuery;
+  it('should stamp the current schema version', () => {
+    expect(query.schemaVersion).toEqual(SCHEMA_VERSION);
+  });
+  it('should convert legacy option values', () => {
+    expect(query.queryOptions!['0']).toEqual({ value: '2' });
+  });
+  it('should remove the token of a stored page', () => {
+    expect(query.nextToken).toBeUndefined();
+  });
+});
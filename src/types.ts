@@ -14,14 +14,23 @@ export interface Metric {
 export interface QueryOptionValue {
   value?: string;
   label?: string;
+  // the values of a MultiEnum option
+  values?: string[];
+  // the value of a DateRange option
+  dateRange?: { from: string; to: string };
 }
 
 // OptionValues are the query options which originate from the backend
 // and are sent along with the query request
 export type QueryOptions = { [key: string]: QueryOptionValue };
 
+// SCHEMA_VERSION is the version of the query model; it must match models.SchemaVersion of the backend
+export const SCHEMA_VERSION = 2;
+
 export interface MyQuery extends DataQuery {
   queryType: QueryType;
+  // the version of the query model; queries of an older version are migrated by the backend
+  schemaVersion?: number;
   dimensions?: Dimensions;
   metrics?: Metric[];
 
@@ -61,6 +70,16 @@ export interface EnumValue {
 export enum OptionType {
   Enum = 'Enum',
   Boolean = 'Boolean',
+  Number = 'Number',
+  Text = 'Text',
+  Duration = 'Duration',
+  DateRange = 'DateRange',
+  MultiEnum = 'MultiEnum',
+}
+
+export interface OptionDependency {
+  optionId: string;
+  values?: string[];
 }
 
 export interface QueryOptionDefinition {
@@ -70,6 +89,12 @@ export interface QueryOptionDefinition {
   type: OptionType;
   enumValues: EnumValue[];
   required: boolean;
+  min?: number;
+  max?: number;
+  step?: number;
+  pattern?: string;
+  dependsOn?: OptionDependency[];
+  defaultValue?: QueryOptionValue;
 }
 
 export type QueryOptionDefinitions = QueryOptionDefinition[];
@@ -86,21 +111,31 @@ export interface DimensionKeyDefinition {
   value?: string;
   label?: string;
   description?: string;
+  group?: string;
+  icon?: string;
+  color?: string;
 }
 
 export interface DimensionValueDefinition {
   value?: string;
   label?: string;
   description?: string;
+  group?: string;
+  icon?: string;
+  color?: string;
 }
 
 export interface MetricDefinition {
   value?: string;
   label?: string;
   description?: string;
+  group?: string;
+  icon?: string;
+  color?: string;
 }
 
 export const defaultQuery: Partial<MyQuery> = {
+  schemaVersion: SCHEMA_VERSION,
   dimensions: [],
   queryType: QueryType.GetMetricAggregate,
   queryOptions: {},